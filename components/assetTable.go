@@ -1,13 +1,17 @@
 package components
 
 import (
-	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
 	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/bep/grouter"
 	"github.com/murdinc/awsm/models"
+	"github.com/murdinc/awsmDashboard/components/forms"
 	"github.com/murdinc/awsmDashboard/helpers"
 )
 
@@ -17,11 +21,14 @@ type AssetTable struct {
 
 // Implements the StateInitializer interface
 func (a AssetTable) GetInitialState() gr.State {
-	return gr.State{"querying": false, "error": "", "assetList": nil}
+	return gr.State{"querying": false, "error": "", "assetList": nil, "tagFilters": nil, "tagColumns": nil, "selected": nil, "editingTags": false,
+		"columns": nil, "sortColumn": "", "sortDesc": false, "viewName": "", "saveName": "", "showColumns": false, "shareURL": ""}
 }
 
 func (a AssetTable) Render() gr.Component {
 
+	state := a.State()
+
 	// Table placeholder
 	response := el.Div()
 
@@ -29,16 +36,77 @@ func (a AssetTable) Render() gr.Component {
 		response,
 	)
 
-	if assets := a.State().Interface("assetList"); assets != nil {
-		table := AssetTableBuilder(assets) // Build the table
+	if assets := state.Interface("assetList"); assets != nil {
+		// A single asset, linked from the command palette
+		focus := helpers.QueryParam("asset")
+		if focus != "" {
+			el.Div(
+				gr.CSS("alert", "alert-info"),
+				gr.Text("Showing "+focus+" "),
+				grouter.Link(helpers.CurrentPath(), "Show all"),
+			).Modify(response)
+		}
+
+		helpers.ErrorElem(state.String("error")).Modify(response)
+
+		// Saved views
+		a.viewBar().Modify(response)
+
+		// Tag filter bar and tag columns
+		tagSets := assetTagSets(assets.([]byte))
+		var allTags []map[string]string
+		for _, tags := range tagSets {
+			allTags = append(allTags, tags)
+		}
+		tagKeys, tagPairs := helpers.TagOptions(allTags)
+		if len(tagKeys) > 0 || len(stringSliceState(state.Interface("tagFilters"))) > 0 {
+			el.Form(
+				evt.KeyDown(forms.DisableEnter),
+				el.Div(
+					gr.CSS("row", "asset-tag-bar"), el.Div(gr.CSS("col-sm-8"),
+						forms.CreateableSelectMultiple("Tag Filters (key=value or key)", "tagFilters", append(tagKeys, tagPairs...), state.Interface("tagFilters"), a.storeSelect),
+					),
+					el.Div(gr.CSS("col-sm-4"),
+						forms.SelectMultiple("Tag Columns", "tagColumns", tagKeys, state.Interface("tagColumns"), a.storeSelect),
+					),
+				),
+			).Modify(response)
+		}
+
+		// Column chooser
+		if state.Bool("showColumns") {
+			a.columnChooser(AssetTableColumns(assets, stringSliceState(state.Interface("tagColumns")))).Modify(response)
+		}
+
+		view := a.tableView()
+		view.Selected = make(map[string]bool)
+		view.OnSort = a.sortBy
+
+		// Bulk tag editing
+		selected := stringSliceState(state.Interface("selected"))
+		if helpers.CanEdit() {
+			view.OnSelect = a.toggleSelected
+			for _, id := range selected {
+				view.Selected[id] = true
+			}
+
+			if len(selected) > 0 {
+				a.bulkActions(selected, tagSets).Modify(response)
+			}
+		}
+
+		// Export
+		a.exportMenu().Modify(response)
+
+		table := AssetTableBuilder(assets, view) // Build the table
 		table.Modify(response)
 
 		el.Break().Modify(response)
 		el.HorizontalRule().Modify(response)
 
-	} else if a.State().Bool("querying") {
+	} else if state.Bool("querying") {
 		gr.Text("Loading...").Modify(response)
-	} else if errStr := a.State().String("error"); errStr != "" {
+	} else if errStr := state.String("error"); errStr != "" {
 		gr.Text(errStr).Modify(response)
 	} else {
 		gr.Text("Nothing here!").Modify(response)
@@ -47,8 +115,75 @@ func (a AssetTable) Render() gr.Component {
 	return elem
 }
 
+// tableView is the focus, filters, columns and sort the table is being shown with
+func (a AssetTable) tableView() AssetTableView {
+	state := a.State()
+
+	view := AssetTableView{
+		Focus:      helpers.QueryParam("asset"),
+		TagColumns: stringSliceState(state.Interface("tagColumns")),
+		Columns:    stringSliceState(state.Interface("columns")),
+		SortColumn: state.String("sortColumn"),
+		SortDesc:   state.Bool("sortDesc"),
+	}
+	for _, filter := range stringSliceState(state.Interface("tagFilters")) {
+		view.TagFilters = append(view.TagFilters, helpers.ParseTagFilter(filter))
+	}
+	if assetType, ok := FindAssetType(a.Props().String("apiType")); ok {
+		view.RowLink = assetType.Detail
+	}
+
+	return view
+}
+
+// bulkActions are the actions on the selected assets
+func (a AssetTable) bulkActions(selected []string, tagSets map[string]map[string]string) *gr.Element {
+	actions := el.Div(gr.CSS("well", "well-sm", "asset-bulk-actions"))
+
+	if a.State().Bool("editingTags") {
+		var selectedTags []map[string]string
+		for _, id := range selected {
+			selectedTags = append(selectedTags, tagSets[id])
+		}
+
+		gr.New(&TagEditor{}).CreateElement(gr.Props{
+			"apiType": a.Props().String("apiType"),
+			"ids":     selected,
+			"tags":    commonTags(selectedTags),
+			"onDone":  a.tagsDone,
+		}).Modify(actions)
+
+		return actions
+	}
+
+	el.Div(
+		gr.CSS("btn-toolbar"),
+		el.Span(gr.CSS("asset-bulk-count"), gr.Text(fmt.Sprintf("%d selected", len(selected)))),
+		el.Button(
+			evt.Click(a.editTagsButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary", "btn-sm"),
+			el.Italic(gr.CSS("fa", "fa-tags")),
+			gr.Text(" Edit Tags"),
+		),
+		el.Button(
+			evt.Click(a.clearSelectionButton).PreventDefault(),
+			gr.CSS("btn", "btn-default", "btn-sm"),
+			gr.Text("Clear Selection"),
+		),
+	).Modify(actions)
+
+	return actions
+}
+
 // Implements the ComponentWillMount interface
 func (a AssetTable) ComponentWillMount() {
+	// A view shared by url
+	if encoded := helpers.QueryParam("view"); encoded != "" {
+		if view, err := helpers.DecodeTableView(encoded); err == nil {
+			a.applyView(view)
+		}
+	}
+
 	if apiType := a.Props().String("apiType"); apiType != "" {
 		a.SetState(gr.State{"querying": true})
 		endpoint := "//localhost:8081/api/assets/" + apiType
@@ -68,181 +203,398 @@ func (a AssetTable) ComponentWillMount() {
 
 // Implements the ShouldComponentUpdate interface.
 func (a AssetTable) ShouldComponentUpdate(this *gr.This, next gr.Cops) bool {
-	return a.State().HasChanged(next.State, "assetList") &&
-		a.State().HasChanged(next.State, "querying") &&
-		a.State().HasChanged(next.State, "error")
+	return a.State().HasChanged(next.State, "assetList", "querying", "error", "tagFilters", "tagColumns", "selected", "editingTags",
+		"columns", "sortColumn", "sortDesc", "viewName", "saveName", "showColumns", "shareURL")
 }
 
-func AssetTableBuilder(al interface{}) *gr.Element {
-	assetList := al.([]byte)
-
-	jsonParsed, _ := gabs.ParseJSON(assetList)
-	assetType := jsonParsed.S("assetType").Data().(string)
-	assets, _ := jsonParsed.S("assets").Children()
-
-	if len(assets) < 1 {
-		return el.Div(gr.Text("Nothing here!"))
+func (a AssetTable) toggleSelected(id string) {
+	var selected []string
+	found := false
+	for _, s := range stringSliceState(a.State().Interface("selected")) {
+		if s == id {
+			found = true
+			continue
+		}
+		selected = append(selected, s)
+	}
+	if !found {
+		selected = append(selected, id)
 	}
+	a.SetState(gr.State{"selected": selected})
+}
 
-	var header []string
-	rows := make([][]string, len(assets))
+func (a AssetTable) editTagsButton(*gr.Event) {
+	a.SetState(gr.State{"editingTags": true})
+}
 
-	switch assetType {
+func (a AssetTable) clearSelectionButton(*gr.Event) {
+	a.SetState(gr.State{"selected": []string{}, "editingTags": false})
+}
 
-	case "alarms":
-		var aType models.Alarm
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+// tagsDone reloads the assets once tags have been changed
+func (a AssetTable) tagsDone(changed bool) {
+	a.SetState(gr.State{"editingTags": false})
+	if !changed {
+		return
+	}
 
-	case "buckets":
-		var aType models.Bucket
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	go func() {
+		endpoint := "//localhost:8081/api/assets/" + a.Props().String("apiType")
+		resp, err := helpers.GetAPI(endpoint)
+		if !a.IsMounted() {
+			return
 		}
-
-	case "instances":
-		var aType models.Instance
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+		if err != nil {
+			a.SetState(gr.State{"error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+			return
 		}
+		a.SetState(gr.State{"assetList": resp, "selected": []string{}})
+	}()
+}
 
-	case "volumes":
-		var aType models.Volume
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+func (a AssetTable) storeSelect(key string, val interface{}) {
+	var vals []string
+	if values, ok := val.([]interface{}); ok {
+		for _, value := range values {
+			if v, ok := value.(map[string]interface{}); ok {
+				vals = append(vals, fmt.Sprint(v["value"]))
+			}
 		}
+	}
+	a.SetState(gr.State{key: vals})
+}
 
-	case "images":
-		var aType models.Image
+// assetTagSets returns the tags of each asset in the list, by asset id
+func assetTagSets(assetList []byte) map[string]map[string]string {
+	tagSets := make(map[string]map[string]string)
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	jsonParsed, _ := gabs.ParseJSON(assetList)
+	apiType, _ := jsonParsed.S("assetType").Data().(string)
+	assetType, _ := FindAssetType(apiType)
+	assets, _ := jsonParsed.S("assets").Children()
+	for _, asset := range assets {
+		if id := assetType.AssetID(asset); id != "" {
+			tagSets[id] = helpers.AssetTags(asset)
 		}
+	}
 
-	case "keypairs":
-		var aType models.KeyPair
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+	return tagSets
+}
 
-	case "snapshots":
-		var aType models.Snapshot
+// commonTags are the tags every one of the tag sets has, as "key=value"
+func commonTags(tagSets []map[string]string) []string {
+	var common []string
+	if len(tagSets) == 0 {
+		return common
+	}
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	for key, value := range tagSets[0] {
+		shared := true
+		for _, tags := range tagSets[1:] {
+			if v, ok := tags[key]; !ok || v != value {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			common = append(common, key+"="+value)
 		}
+	}
+	sort.Strings(common)
 
-	case "vpcs":
-		var aType models.Vpc
+	return common
+}
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+// AssetTableView narrows and decorates an asset table
+type AssetTableView struct {
+	Focus      string              // only rows with a cell matching this, when given
+	TagFilters []helpers.TagFilter // only assets with all of these tags
+	TagColumns []string            // tag keys to show as columns
+	Selected   map[string]bool     // asset ids selected for bulk actions
+	OnSelect   func(id string)     // shows a checkbox column when set
+	Columns    []string            // shown columns in order, every column when empty
+	SortColumn string
+	SortDesc   bool
+	OnSort     func(column string)                // makes the headers sortable when set
+	RowLink    func(asset *gabs.Container) string // links the first cell of each row when set
+}
 
-	case "subnets":
-		var aType models.Subnet
+// AssetTableData is an asset list narrowed, sorted and cut down to the chosen columns, exactly as the table shows it
+type AssetTableData struct {
+	AssetType string
+	Header    []string
+	Rows      [][]string
+	Assets    []*gabs.Container // the asset behind each row
+	Total     []string          // the monthly cost total, for asset types with prices
+	Count     int               // assets before filtering
+}
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+// BuildAssetTableData applies the view to an asset list
+func BuildAssetTableData(al interface{}, view AssetTableView) AssetTableData {
+	assetList := al.([]byte)
 
-	case "securitygroups":
-		var aType models.SecurityGroup
+	jsonParsed, _ := gabs.ParseJSON(assetList)
+	assetType, _ := jsonParsed.S("assetType").Data().(string)
+	assets, _ := jsonParsed.S("assets").Children()
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+	data := AssetTableData{AssetType: assetType, Count: len(assets)}
+	if len(assets) < 1 {
+		return data
+	}
 
-	case "addresses":
-		var aType models.Address
+	header, rows := extractAssetTable(assetType, assets)
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	// Tag columns
+	header = append(header, view.TagColumns...)
+	tagSets := make([]map[string]string, len(assets))
+	for i, a := range assets {
+		tagSets[i] = helpers.AssetTags(a)
+		for _, key := range view.TagColumns {
+			rows[i] = append(rows[i], tagSets[i][key])
 		}
+	}
 
-	case "launchconfigurations":
-		var aType models.LaunchConfig
+	// Rows that pass the focus and tag filters, the focus is an asset id
+	t, _ := FindAssetType(assetType)
+	var shown []int
+	for i := range rows {
+		if view.Focus != "" && t.AssetID(assets[i]) != view.Focus {
+			continue
+		}
+		if !helpers.MatchesTagFilters(tagSets[i], view.TagFilters) {
+			continue
+		}
+		shown = append(shown, i)
+	}
 
+	// Estimated monthly cost, for the asset types we have prices for
+	var totalRow []string
+	if helpers.HasAssetCost(assetType) {
+		total := 0.0
+		header = append(header, "Monthly Cost")
 		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+			cost, ok := helpers.AssetMonthlyCost(assetType, a)
+			if !ok {
+				rows[i] = append(rows[i], "-")
+				continue
+			}
+			if rowShown(shown, i) {
+				total += cost
+			}
+			rows[i] = append(rows[i], helpers.FormatCost(cost))
 		}
 
-	case "autoscalegroups":
-		var aType models.AutoScaleGroup
+		totalRow = make([]string, len(header))
+		totalRow[len(header)-1] = helpers.FormatCost(total)
+	}
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+	// Sort
+	if sortIndex := indexOf(header, view.SortColumn); sortIndex >= 0 {
+		sort.Stable(assetRowSorter{rows: rows, shown: shown, column: sortIndex, desc: view.SortDesc})
+	}
 
-	case "loadbalancers":
-		var aType models.LoadBalancer
+	// Columns, in the chosen order
+	columns := visibleColumns(header, view.Columns)
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	for _, c := range columns {
+		data.Header = append(data.Header, header[c])
+	}
+	for _, i := range shown {
+		row := make([]string, len(columns))
+		for n, c := range columns {
+			row[n] = rows[i][c]
+		}
+		data.Rows = append(data.Rows, row)
+		data.Assets = append(data.Assets, assets[i])
+	}
+	if totalRow != nil && len(shown) > 0 {
+		data.Total = make([]string, len(columns))
+		for n, c := range columns {
+			data.Total[n] = totalRow[c]
+		}
+		if data.Total[0] == "" {
+			data.Total[0] = "Total"
 		}
+	}
 
-	case "scalingpolicies":
-		var aType models.ScalingPolicy
+	return data
+}
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
-		}
+// AssetTableBuilder builds the table for an asset list
+func AssetTableBuilder(al interface{}, view AssetTableView) *gr.Element {
+	data := BuildAssetTableData(al, view)
+
+	if data.Count < 1 {
+		return el.Div(gr.Text("Nothing here!"))
+	}
+	if len(data.Rows) < 1 {
+		return el.Div(gr.Text("No assets match the tag filters!"))
+	}
 
-	case "simpledbdomains":
-		var aType models.SimpleDBDomain
+	selectable := view.OnSelect != nil
 
-		for i, a := range assets {
-			aJson := a.Bytes()
-			json.Unmarshal(aJson, &aType)
-			models.ExtractAwsmTable(i, aType, &header, &rows)
+	tHeadRow := el.TableRow()
+	if selectable {
+		el.TableHeader().Modify(tHeadRow)
+	}
+	for _, name := range data.Header {
+		if view.OnSort == nil {
+			el.TableHeader(gr.Text(name)).Modify(tHeadRow)
+			continue
 		}
 
-	default:
-		println("Asset Type not found in AssetTableBuilder switch:")
-		println(assetType)
+		column := name
+		th := el.TableHeader(
+			gr.CSS("sortable"),
+			evt.Click(func(*gr.Event) { view.OnSort(column) }),
+			gr.Text(name+" "),
+		)
+		switch {
+		case name == view.SortColumn && view.SortDesc:
+			el.Italic(gr.CSS("fa", "fa-sort-desc")).Modify(th)
+		case name == view.SortColumn:
+			el.Italic(gr.CSS("fa", "fa-sort-asc")).Modify(th)
+		}
+		th.Modify(tHeadRow)
 	}
 
 	tBody := el.TableBody()
 
-	helpers.BuildTableRows(rows, tBody)
+	assetType, _ := FindAssetType(data.AssetType)
+	for r, row := range data.Rows {
+		tr := el.TableRow()
+		if selectable {
+			// Assets without an id can't be tagged, they get an empty cell
+			td := el.TableData()
+			if id := assetType.AssetID(data.Assets[r]); id != "" {
+				el.Input(
+					attr.Type("checkbox"),
+					attr.Checked(view.Selected[id]),
+					evt.Change(func(*gr.Event) { view.OnSelect(id) }),
+				).Modify(td)
+				if view.Selected[id] {
+					gr.CSS("info").Modify(tr)
+				}
+			}
+			td.Modify(tr)
+		}
+		for c, cell := range row {
+			if c == 0 && view.RowLink != nil {
+				el.TableData(grouter.Link(view.RowLink(data.Assets[r]), cell)).Modify(tr)
+				continue
+			}
+			el.TableData(gr.Text(cell)).Modify(tr)
+		}
+		tr.Modify(tBody)
+	}
+
+	if data.Total != nil {
+		total := data.Total
+		if selectable {
+			total = append([]string{""}, total...)
+		}
+		helpers.BuildTableRows([][]string{total}, tBody)
+	}
 
 	table := el.Table(
 		gr.CSS("table", "table-striped"),
 		gr.Style("width", "100%"),
-		el.TableHead(el.TableRow(helpers.BuildTableHeader(header)...)))
+		el.TableHead(tHeadRow))
 
 	tBody.Modify(table)
 
 	return table
 }
+
+// AssetTableColumns returns every column an asset list can show, given the tag columns
+func AssetTableColumns(al interface{}, tagColumns []string) []string {
+	jsonParsed, _ := gabs.ParseJSON(al.([]byte))
+	assetType, _ := jsonParsed.S("assetType").Data().(string)
+	assets, _ := jsonParsed.S("assets").Children()
+
+	header, _ := extractAssetTable(assetType, assets)
+	header = append(header, tagColumns...)
+	if len(assets) > 0 && helpers.HasAssetCost(assetType) {
+		header = append(header, "Monthly Cost")
+	}
+
+	return header
+}
+
+// visibleColumns returns the indexes of the chosen columns in order, or every column when none are chosen
+func visibleColumns(header []string, chosen []string) []int {
+	var columns []int
+	for _, name := range chosen {
+		if i := indexOf(header, name); i >= 0 {
+			columns = append(columns, i)
+		}
+	}
+	if len(columns) == 0 {
+		for i := range header {
+			columns = append(columns, i)
+		}
+	}
+	return columns
+}
+
+func indexOf(list []string, s string) int {
+	if s == "" {
+		return -1
+	}
+	for i, l := range list {
+		if l == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// assetRowSorter sorts the shown row indexes by a column
+type assetRowSorter struct {
+	rows   [][]string
+	shown  []int
+	column int
+	desc   bool
+}
+
+func (a assetRowSorter) Len() int      { return len(a.shown) }
+func (a assetRowSorter) Swap(i, j int) { a.shown[i], a.shown[j] = a.shown[j], a.shown[i] }
+func (a assetRowSorter) Less(i, j int) bool {
+	cmp := helpers.CompareCells(a.rows[a.shown[i]][a.column], a.rows[a.shown[j]][a.column])
+	if a.desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+func rowShown(shown []int, i int) bool {
+	for _, s := range shown {
+		if s == i {
+			return true
+		}
+	}
+	return false
+}
+
+// extractAssetTable builds the columns awsm defines for the asset type, and a row for each asset
+func extractAssetTable(assetType string, assets []*gabs.Container) ([]string, [][]string) {
+	var header []string
+	rows := make([][]string, len(assets))
+
+	t, ok := FindAssetType(assetType)
+	switch {
+	case !ok:
+		println("Asset Type not found in AssetTypes:")
+		println(assetType)
+
+	case t.Table != nil:
+		return t.Table(assets)
+
+	default:
+		for i, a := range assets {
+			models.ExtractAwsmTable(i, decodeAs(t.Model, a.Bytes()), &header, &rows)
+		}
+	}
+
+	return header, rows
+}
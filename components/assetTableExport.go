@@ -0,0 +1,72 @@
+package components
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// exportMenu downloads the rows the table is showing
+func (a AssetTable) exportMenu() *gr.Element {
+	menu := el.UnorderedList(gr.CSS("dropdown-menu", "dropdown-menu-right"))
+	el.ListItem(el.Anchor(attr.HRef("#"), evt.Click(a.exportButton("csv")).PreventDefault(), gr.Text("CSV"))).Modify(menu)
+	el.ListItem(el.Anchor(attr.HRef("#"), evt.Click(a.exportButton("json")).PreventDefault(), gr.Text("JSON"))).Modify(menu)
+	el.ListItem(el.Anchor(attr.HRef("#"), evt.Click(a.exportButton("md")).PreventDefault(), gr.Text("Markdown"))).Modify(menu)
+
+	return el.Div(
+		gr.CSS("asset-table-toolbar", "clearfix"),
+		el.Div(
+			gr.CSS("btn-group", "dropdown", "pull-right"),
+			el.Button(
+				gr.CSS("btn", "btn-default", "btn-sm", "dropdown-toggle"),
+				gr.Data("toggle", "dropdown"),
+				el.Italic(gr.CSS("fa", "fa-download")),
+				gr.Text(" Export "),
+				el.Span(gr.CSS("caret")),
+			),
+			menu,
+		),
+	)
+}
+
+func (a AssetTable) exportButton(format string) func(*gr.Event) {
+	return func(*gr.Event) {
+		assets, ok := a.State().Interface("assetList").([]byte)
+		if !ok {
+			return
+		}
+
+		data := BuildAssetTableData(assets, a.tableView())
+		fileName := "awsm-" + strings.Replace(a.Props().String("apiType"), " ", "-", -1)
+
+		switch format {
+		case "csv":
+			rows := data.Rows
+			if data.Total != nil {
+				rows = append(rows, data.Total)
+			}
+			helpers.DownloadFile(fileName+".csv", "text/csv", helpers.TableCSV(data.Header, rows))
+
+		case "json":
+			// The assets as awsm returned them, rather than the table cells
+			raw := make([]interface{}, len(data.Assets))
+			for i, asset := range data.Assets {
+				raw[i] = asset.Data()
+			}
+			out, _ := json.MarshalIndent(raw, "", "  ")
+			helpers.DownloadFile(fileName+".json", "application/json", out)
+
+		case "md":
+			rows := data.Rows
+			if data.Total != nil {
+				rows = append(rows, data.Total)
+			}
+			helpers.DownloadFile(fileName+".md", "text/markdown", helpers.TableMarkdown(data.Header, rows))
+		}
+	}
+}
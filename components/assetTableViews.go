@@ -0,0 +1,264 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// viewBar picks, saves, deletes and shares the saved views of an asset page
+func (a AssetTable) viewBar() *gr.Element {
+	state := a.State()
+
+	var names []string
+	for _, view := range helpers.LoadTableViews(a.Props().String("apiType")) {
+		names = append(names, view.Name)
+	}
+
+	columnsCSS := gr.CSS("btn", "btn-default", "btn-block")
+	if state.Bool("showColumns") {
+		columnsCSS = gr.CSS("btn", "btn-default", "btn-block", "active")
+	}
+
+	bar := el.Form(
+		evt.KeyDown(forms.DisableEnter),
+		el.Div(
+			gr.CSS("row", "asset-view-bar"), el.Div(gr.CSS("col-sm-3"),
+				forms.SelectOne("View", "viewName", names, state.Interface("viewName"), a.selectView),
+			),
+			el.Div(gr.CSS("col-sm-3"),
+				forms.TextField("View Name", "saveName", state.String("saveName"), a.storeValue),
+			),
+			el.Div(gr.CSS("col-sm-6"),
+				el.Label(gr.Text(" ")),
+				el.Div(
+					gr.CSS("btn-group", "btn-group-justified"),
+					el.Div(gr.CSS("btn-group"), el.Button(evt.Click(a.saveViewButton).PreventDefault(), gr.CSS("btn", "btn-default"), gr.Text("Save View"))),
+					el.Div(gr.CSS("btn-group"), el.Button(evt.Click(a.deleteViewButton).PreventDefault(), gr.CSS("btn", "btn-default"), gr.Text("Delete View"))),
+					el.Div(gr.CSS("btn-group"), el.Button(evt.Click(a.shareViewButton).PreventDefault(), gr.CSS("btn", "btn-default"), gr.Text("Share"))),
+					el.Div(gr.CSS("btn-group"), el.Button(evt.Click(a.toggleColumnsButton).PreventDefault(), columnsCSS, el.Italic(gr.CSS("fa", "fa-columns")), gr.Text(" Columns"))),
+				),
+			),
+		),
+	)
+
+	if shareURL := state.String("shareURL"); shareURL != "" {
+		el.Div(
+			gr.CSS("form-group"),
+			el.Label(gr.Text("Share this link")),
+			el.Input(
+				attr.Type("text"),
+				attr.ClassName("form-control"),
+				attr.Value(shareURL),
+				gr.Prop("readOnly", true),
+				evt.Focus(selectText),
+			),
+		).Modify(bar)
+	}
+
+	return bar
+}
+
+// columnChooser shows, hides and reorders the columns of the table
+func (a AssetTable) columnChooser(header []string) *gr.Element {
+	chosen := stringSliceState(a.State().Interface("columns"))
+	if len(chosen) == 0 {
+		chosen = header
+	}
+
+	// Shown columns in their order, then the hidden ones
+	var ordered []string
+	for _, name := range chosen {
+		if indexOf(header, name) >= 0 {
+			ordered = append(ordered, name)
+		}
+	}
+	shownCount := len(ordered)
+	for _, name := range header {
+		if indexOf(ordered, name) < 0 {
+			ordered = append(ordered, name)
+		}
+	}
+
+	list := el.UnorderedList(gr.CSS("list-group", "column-chooser"))
+	for i, name := range ordered {
+		shown := i < shownCount
+
+		item := el.ListItem(
+			gr.CSS("list-group-item"),
+			el.Input(
+				attr.Type("checkbox"),
+				attr.Checked(shown),
+				evt.Change(a.toggleColumn(ordered[:shownCount], name)),
+			),
+			gr.Text(" "+name),
+		)
+
+		if shown {
+			buttons := el.Span(gr.CSS("pull-right"))
+			if i > 0 {
+				el.Button(
+					evt.Click(a.moveColumn(ordered[:shownCount], i, -1)).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-xs"),
+					el.Italic(gr.CSS("fa", "fa-arrow-up")),
+				).Modify(buttons)
+			}
+			if i < shownCount-1 {
+				el.Button(
+					evt.Click(a.moveColumn(ordered[:shownCount], i, 1)).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-xs"),
+					el.Italic(gr.CSS("fa", "fa-arrow-down")),
+				).Modify(buttons)
+			}
+			buttons.Modify(item)
+		} else {
+			gr.CSS("list-group-item", "disabled").Modify(item)
+		}
+
+		item.Modify(list)
+	}
+
+	return el.Div(
+		gr.CSS("panel", "panel-default"),
+		el.Div(
+			gr.CSS("panel-heading"),
+			el.Button(
+				evt.Click(a.resetColumnsButton).PreventDefault(),
+				gr.CSS("btn", "btn-default", "btn-xs", "pull-right"),
+				gr.Text("Show All"),
+			),
+			gr.Text("Columns"),
+		),
+		list,
+	)
+}
+
+// currentView is the table as it is being looked at now
+func (a AssetTable) currentView(name string) helpers.TableView {
+	state := a.State()
+	return helpers.TableView{
+		Name:       name,
+		Columns:    stringSliceState(state.Interface("columns")),
+		TagFilters: stringSliceState(state.Interface("tagFilters")),
+		TagColumns: stringSliceState(state.Interface("tagColumns")),
+		SortColumn: state.String("sortColumn"),
+		SortDesc:   state.Bool("sortDesc"),
+	}
+}
+
+func (a AssetTable) applyView(view helpers.TableView) {
+	a.SetState(gr.State{
+		"viewName":   view.Name,
+		"saveName":   view.Name,
+		"columns":    view.Columns,
+		"tagFilters": view.TagFilters,
+		"tagColumns": view.TagColumns,
+		"sortColumn": view.SortColumn,
+		"sortDesc":   view.SortDesc,
+		"shareURL":   "",
+	})
+}
+
+func (a AssetTable) selectView(key string, val interface{}) {
+	value, ok := val.(map[string]interface{})
+	if !ok {
+		a.applyView(helpers.TableView{})
+		return
+	}
+
+	name, _ := value["value"].(string)
+	if view, ok := helpers.FindTableView(a.Props().String("apiType"), name); ok {
+		a.applyView(view)
+	}
+}
+
+func (a AssetTable) saveViewButton(*gr.Event) {
+	name := strings.TrimSpace(a.State().String("saveName"))
+	if name == "" {
+		a.SetState(gr.State{"error": "Name the view to save it!"})
+		return
+	}
+
+	helpers.SaveTableView(a.Props().String("apiType"), a.currentView(name))
+	a.SetState(gr.State{"viewName": name, "error": ""})
+}
+
+func (a AssetTable) deleteViewButton(*gr.Event) {
+	name := a.State().String("viewName")
+	if name == "" {
+		return
+	}
+
+	helpers.DeleteTableView(a.Props().String("apiType"), name)
+	a.SetState(gr.State{"viewName": "", "saveName": ""})
+}
+
+func (a AssetTable) shareViewButton(*gr.Event) {
+	location := js.Global.Get("location")
+	shareURL := location.Get("protocol").String() + "//" + location.Get("host").String() + helpers.CurrentPath() +
+		"?view=" + helpers.EncodeTableView(a.currentView(a.State().String("viewName")))
+
+	a.SetState(gr.State{"shareURL": shareURL})
+}
+
+func (a AssetTable) toggleColumnsButton(*gr.Event) {
+	a.SetState(gr.State{"showColumns": !a.State().Bool("showColumns")})
+}
+
+func (a AssetTable) resetColumnsButton(*gr.Event) {
+	a.SetState(gr.State{"columns": []string{}})
+}
+
+func (a AssetTable) toggleColumn(shown []string, name string) func(*gr.Event) {
+	return func(*gr.Event) {
+		var columns []string
+		for _, column := range shown {
+			if column != name {
+				columns = append(columns, column)
+			}
+		}
+		if len(columns) == len(shown) {
+			columns = append(columns, name)
+		}
+		if len(columns) == 0 {
+			return // always keep one column
+		}
+		a.SetState(gr.State{"columns": columns})
+	}
+}
+
+func (a AssetTable) moveColumn(shown []string, i, by int) func(*gr.Event) {
+	return func(*gr.Event) {
+		columns := append([]string{}, shown...)
+		columns[i], columns[i+by] = columns[i+by], columns[i]
+		a.SetState(gr.State{"columns": columns})
+	}
+}
+
+func (a AssetTable) sortBy(column string) {
+	state := a.State()
+	if state.String("sortColumn") == column {
+		if state.Bool("sortDesc") {
+			a.SetState(gr.State{"sortColumn": "", "sortDesc": false})
+			return
+		}
+		a.SetState(gr.State{"sortDesc": true})
+		return
+	}
+	a.SetState(gr.State{"sortColumn": column, "sortDesc": false})
+}
+
+func (a AssetTable) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	a.SetState(gr.State{key: event.TargetValue().String()})
+}
+
+func selectText(event *gr.Event) {
+	event.Target().Call("select")
+}
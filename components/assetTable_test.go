@@ -0,0 +1,36 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildAssetTableDataFocus(t *testing.T) {
+	// The second certificate is named after the first one's arn, focusing on an arn must only show its own asset
+	assetList := []byte(`{"assetType": "certificates", "assets": [
+		{"name": "www", "arn": "arn:aws:acm:us-east-1:1:certificate/1"},
+		{"name": "arn:aws:acm:us-east-1:1:certificate/1", "arn": "arn:aws:acm:us-east-1:1:certificate/2"}
+	]}`)
+
+	tests := []struct {
+		focus string
+		want  []string
+	}{
+		{"", []string{"www", "arn:aws:acm:us-east-1:1:certificate/1"}},
+		{"arn:aws:acm:us-east-1:1:certificate/1", []string{"www"}},
+		{"arn:aws:acm:us-east-1:1:certificate/2", []string{"arn:aws:acm:us-east-1:1:certificate/1"}},
+		{"www", nil},
+	}
+
+	for _, test := range tests {
+		data := BuildAssetTableData(assetList, AssetTableView{Focus: test.focus})
+
+		var names []string
+		for _, row := range data.Rows {
+			names = append(names, row[0])
+		}
+		if !reflect.DeepEqual(names, test.want) {
+			t.Errorf("BuildAssetTableData(focus %q) shows %q, want %q", test.focus, names, test.want)
+		}
+	}
+}
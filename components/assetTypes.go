@@ -0,0 +1,273 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/murdinc/awsm/config"
+	"github.com/murdinc/awsm/models"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// AssetType registers everything the dashboard knows about one awsm asset type. A type listed in AssetTypes gets a
+// page, an asset table and, when it has a class, the class list and class forms
+type AssetType struct {
+	ApiType string // api path, "instances"
+	Name    string // page and nav name, "Instances"
+	Type    string // singular, "Instance"
+	Route   string
+	Icon    string // font awesome icon, "fa-server"
+	Group   string // nav group, one of NavGroups
+	IDKey   string // field holding each asset's own id, "instanceID"
+
+	// Detail returns the path of a page about one asset, for types that have one
+	Detail func(asset *gabs.Container) string
+
+	// Model is the awsm models struct each asset is read into for its table row. Table replaces it for types awsm has
+	// no model for
+	Model interface{}
+	Table func(assets []*gabs.Container) ([]string, [][]string)
+
+	// Class is the awsm config struct for the type's classes, and Form edits them. Both are nil for types without
+	// classes
+	Class interface{}
+	Form  func() *gr.ReactComponent
+}
+
+// HasClasses returns true if the type has classes that can be listed and edited
+func (a AssetType) HasClasses() bool {
+	return a.Class != nil && a.Form != nil
+}
+
+// AssetID returns the asset's own id, read from the type's IDKey
+func (a AssetType) AssetID(asset *gabs.Container) string {
+	return helpers.AssetID(asset, a.IDKey)
+}
+
+// Page is the page the type gets, order is its position in the nav
+func (a AssetType) Page(order int) Page {
+	return Page{
+		Route:      a.Route,
+		ApiType:    a.ApiType,
+		Type:       a.Type,
+		Icon:       a.Icon,
+		Group:      a.Group,
+		Order:      order,
+		HasClasses: a.HasClasses(),
+		HasAssets:  true,
+	}
+}
+
+// AssetTypes is the registry of asset types
+var AssetTypes = []AssetType{
+	{
+		ApiType: "instances", Name: "Instances", Type: "Instance", Route: "/instances", Icon: "fa-server", Group: "Compute",
+		Model: models.Instance{}, IDKey: "instanceID",
+		Class: config.InstanceClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.InstanceClassForm{}) },
+	},
+	{
+		ApiType: "images", Name: "Images", Type: "Image", Route: "/images", Icon: "fa-clone", Group: "Compute",
+		Model: models.Image{}, IDKey: "imageID",
+		Class: config.ImageClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.ImageClassForm{}) },
+	},
+	{
+		ApiType: "keypairs", Name: "Key Pairs", Type: "Key Pair", Route: "/keypairs", Icon: "fa-key", Group: "Compute",
+		Model: models.KeyPair{}, IDKey: "keyName",
+		Class: config.KeyPairClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.KeyPairClassForm{}) },
+	},
+	{
+		ApiType: "launchconfigurations", Name: "Launch Configurations", Type: "Launch Configuration", Route: "/launchconfigurations", Icon: "fa-rocket", Group: "Compute",
+		Model: models.LaunchConfig{}, IDKey: "name",
+		Class: config.LaunchConfigurationClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.LaunchConfigurationClassForm{}) },
+	},
+	{
+		ApiType: "volumes", Name: "Volumes", Type: "Volume", Route: "/volumes", Icon: "fa-hdd-o", Group: "Storage",
+		Model: models.Volume{}, IDKey: "volumeID",
+		Class: config.VolumeClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.VolumeClassForm{}) },
+	},
+	{
+		ApiType: "snapshots", Name: "Snapshots", Type: "Snapshot", Route: "/snapshots", Icon: "fa-camera", Group: "Storage",
+		Model: models.Snapshot{}, IDKey: "snapshotID",
+		Class: config.SnapshotClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.SnapshotClassForm{}) },
+	},
+	{
+		ApiType: "buckets", Name: "S3 Buckets", Type: "S3 Buckets", Route: "/buckets", Icon: "fa-archive", Group: "Storage",
+		Model: models.Bucket{}, IDKey: "name",
+	},
+	{
+		ApiType: "simpledbdomains", Name: "SimpleDB Domains", Type: "SimpleDB Domain", Route: "/simpledbdomains", Icon: "fa-database", Group: "Storage",
+		Model: models.SimpleDBDomain{}, IDKey: "name",
+	},
+	{
+		ApiType: "vpcs", Name: "Vpcs", Type: "Vpc", Route: "/vpcs", Icon: "fa-cloud", Group: "Network",
+		Model: models.Vpc{}, Detail: vpcTopologyPath, IDKey: "vpcID",
+		Class: config.VpcClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.VpcClassForm{}) },
+	},
+	{
+		ApiType: "subnets", Name: "Subnets", Type: "Subnet", Route: "/subnets", Icon: "fa-sitemap", Group: "Network",
+		Model: models.Subnet{}, IDKey: "subnetID",
+		Class: config.SubnetClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.SubnetClassForm{}) },
+	},
+	{
+		ApiType: "securitygroups", Name: "Security Groups", Type: "Security Group", Route: "/securitygroups", Icon: "fa-shield", Group: "Network",
+		Model: models.SecurityGroup{}, IDKey: "groupID",
+		Class: config.SecurityGroupClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.SecurityGroupClassForm{}) },
+	},
+	{
+		ApiType: "addresses", Name: "Addresses", Type: "Address", Route: "/addresses", Icon: "fa-map-marker", Group: "Network",
+		Model: models.Address{}, IDKey: "allocationID",
+	},
+	{
+		ApiType: "loadbalancers", Name: "Load Balancers", Type: "Load Balancer", Route: "/loadbalancers", Icon: "fa-random", Group: "Network",
+		Model: models.LoadBalancer{}, IDKey: "name",
+		Class: config.LoadBalancerClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.LoadBalancerClassForm{}) },
+	},
+	{
+		ApiType: "certificates", Name: "Certificates", Type: "Certificate", Route: "/certificates", Icon: "fa-certificate", Group: "Network",
+		Table: certificateTable, IDKey: "arn",
+	},
+	{
+		ApiType: "alarms", Name: "Alarms", Type: "Alarm", Route: "/alarms", Icon: "fa-bell", Group: "Monitoring",
+		Model: models.Alarm{}, IDKey: "name",
+		Class: config.AlarmClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.AlarmClassForm{}) },
+	},
+	{
+		ApiType: "autoscalegroups", Name: "Autoscale Groups", Type: "Autoscale Group", Route: "/autoscalegroups", Icon: "fa-expand", Group: "Scaling",
+		Model: models.AutoScaleGroup{}, IDKey: "name",
+		Class: config.AutoscaleGroupClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.AutoscaleGroupClassForm{}) },
+	},
+	{
+		ApiType: "scalingpolicies", Name: "Scaling Policies", Type: "Scaling Policy", Route: "/scalingpolicies", Icon: "fa-line-chart", Group: "Scaling",
+		Model: models.ScalingPolicy{}, IDKey: "arn",
+		Class: config.ScalingPolicyClass{}, Form: func() *gr.ReactComponent { return gr.New(&forms.ScalingPolicyClassForm{}) },
+	},
+}
+
+// FindAssetType looks up a registered asset type by its api type
+func FindAssetType(apiType string) (AssetType, bool) {
+	for _, assetType := range AssetTypes {
+		if assetType.ApiType == apiType {
+			return assetType, true
+		}
+	}
+	return AssetType{}, false
+}
+
+// ClassTypes returns the api types of every registered type with classes, sorted
+func ClassTypes() []string {
+	var classTypes []string
+	for _, assetType := range AssetTypes {
+		if assetType.HasClasses() {
+			classTypes = append(classTypes, assetType.ApiType)
+		}
+	}
+	sort.Strings(classTypes)
+	return classTypes
+}
+
+// AssetTypePages returns the page of every registered type, for AppPages. They are ordered as registered, after
+// any page with an Order under 100
+func AssetTypePages() Pages {
+	pages := make(Pages)
+	for i, assetType := range AssetTypes {
+		pages[assetType.Name] = assetType.Page(assetTypeOrder(i))
+	}
+	return pages
+}
+
+func assetTypeOrder(i int) int {
+	return 100 + i*10
+}
+
+// CheckAssetTypes looks for registrations that would silently drop a page, table, class list or form, and returns a
+// description of each problem. It runs at startup against the final pages map
+func CheckAssetTypes(pages Pages) []string {
+	var problems []string
+
+	apiTypes := make(map[string]bool)
+	routes := make(map[string]string)
+	for name, page := range pages {
+		if other, ok := routes[page.Route]; ok {
+			problems = append(problems, fmt.Sprintf("pages %q and %q share the route %s", name, other, page.Route))
+		}
+		routes[page.Route] = name
+	}
+
+	for i, assetType := range AssetTypes {
+		switch {
+		case assetType.ApiType == "" || assetType.Name == "" || assetType.Type == "" || assetType.Route == "":
+			problems = append(problems, fmt.Sprintf("asset type %q is missing its api type, name, type or route", assetType.ApiType))
+		case apiTypes[assetType.ApiType]:
+			problems = append(problems, fmt.Sprintf("asset type %q is registered twice", assetType.ApiType))
+		}
+		apiTypes[assetType.ApiType] = true
+
+		if assetType.IDKey == "" {
+			problems = append(problems, fmt.Sprintf("asset type %q has no id key", assetType.ApiType))
+		}
+		if indexOf(NavGroups, assetType.Group) < 0 {
+			problems = append(problems, fmt.Sprintf("asset type %q is in the unknown nav group %q", assetType.ApiType, assetType.Group))
+		}
+		if assetType.Model == nil && assetType.Table == nil {
+			problems = append(problems, fmt.Sprintf("asset type %q has neither a model nor a table", assetType.ApiType))
+		}
+		if (assetType.Class == nil) != (assetType.Form == nil) {
+			problems = append(problems, fmt.Sprintf("asset type %q needs both a class and a form, or neither", assetType.ApiType))
+		}
+
+		page, ok := pages[assetType.Name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("asset type %q has no page", assetType.ApiType))
+			continue
+		}
+		if page != assetType.Page(assetTypeOrder(i)) {
+			problems = append(problems, fmt.Sprintf("page %q does not match the %q registration", assetType.Name, assetType.ApiType))
+		}
+	}
+
+	for name, page := range pages {
+		if page.Icon == "" {
+			problems = append(problems, fmt.Sprintf("page %q has no icon", name))
+		}
+		if page.Group != "" && indexOf(NavGroups, page.Group) < 0 {
+			problems = append(problems, fmt.Sprintf("page %q is in the unknown nav group %q", name, page.Group))
+		}
+		if (page.HasAssets || page.HasClasses) && !apiTypes[page.ApiType] {
+			problems = append(problems, fmt.Sprintf("page %q shows %q assets, which is not a registered asset type", name, page.ApiType))
+		}
+	}
+
+	sort.Strings(problems)
+
+	return problems
+}
+
+// decodeAs reads json into a fresh value of the same type as sample, a registered Model or Class
+func decodeAs(sample interface{}, data []byte) interface{} {
+	value := reflect.New(reflect.TypeOf(sample))
+	json.Unmarshal(data, value.Interface())
+	return value.Elem().Interface()
+}
+
+// certificateTable lists IAM server certificates and ACM certificates together, awsm has no model for them
+func certificateTable(assets []*gabs.Container) ([]string, [][]string) {
+	header := []string{"Name", "Source", "Domain Name", "Status", "Expiration", "ARN"}
+	keys := []string{"name", "source", "domainName", "status", "expiration", "arn"}
+
+	rows := make([][]string, len(assets))
+	for i, a := range assets {
+		rows[i] = make([]string, len(keys))
+		for k, key := range keys {
+			if val := a.S(key).Data(); val != nil {
+				rows[i][k] = fmt.Sprint(val)
+			}
+		}
+	}
+
+	return header, rows
+}
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Jeffail/gabs"
+)
+
+func TestCheckAssetTypes(t *testing.T) {
+	if problems := CheckAssetTypes(AppPages()); len(problems) > 0 {
+		t.Errorf("CheckAssetTypes() found problems in the served pages:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestAssetTypeRegistrations(t *testing.T) {
+	for _, assetType := range AssetTypes {
+		if assetType.Model == nil && assetType.Table == nil {
+			t.Errorf("%s: has neither a Model nor a Table", assetType.ApiType)
+		}
+		if assetType.Model != nil {
+			if typ := reflect.TypeOf(assetType.Model); typ.Kind() != reflect.Struct || typ.PkgPath() != "github.com/murdinc/awsm/models" {
+				t.Errorf("%s: Model is a %s, want an awsm models struct", assetType.ApiType, typ)
+			}
+		}
+
+		if assetType.Class != nil {
+			if typ := reflect.TypeOf(assetType.Class); typ.Kind() != reflect.Struct || typ.PkgPath() != "github.com/murdinc/awsm/config" {
+				t.Errorf("%s: Class is a %s, want an awsm config struct", assetType.ApiType, typ)
+			}
+			if assetType.Form == nil {
+				t.Errorf("%s: has a Class but no Form", assetType.ApiType)
+			}
+		} else if assetType.Form != nil {
+			t.Errorf("%s: has a Form but no Class", assetType.ApiType)
+		}
+
+		if found, ok := FindAssetType(assetType.ApiType); !ok || found.Name != assetType.Name {
+			t.Errorf("FindAssetType(%q) = %q, %t, want %q", assetType.ApiType, found.Name, ok, assetType.Name)
+		}
+	}
+}
+
+func TestClassTypes(t *testing.T) {
+	var want []string
+	for _, assetType := range AssetTypes {
+		if assetType.Class != nil {
+			want = append(want, assetType.ApiType)
+		}
+	}
+	sort.Strings(want)
+
+	if got := ClassTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ClassTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckAssetTypesProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(pages Pages)
+		want   string
+	}{
+		{
+			name:   "missing page",
+			modify: func(pages Pages) { delete(pages, "Instances") },
+			want:   `asset type "instances" has no page`,
+		},
+		{
+			name: "changed page",
+			modify: func(pages Pages) {
+				page := pages["Instances"]
+				page.HasClasses = false
+				pages["Instances"] = page
+			},
+			want: `page "Instances" does not match the "instances" registration`,
+		},
+		{
+			name:   "shared route",
+			modify: func(pages Pages) { pages["Servers"] = Page{Route: "/instances", Icon: "fa-server"} },
+			want:   "share the route /instances",
+		},
+		{
+			name:   "unknown nav group",
+			modify: func(pages Pages) { pages["Dashboard"] = Page{Route: "/", Icon: "fa-tachometer", Group: "Nowhere"} },
+			want:   `page "Dashboard" is in the unknown nav group "Nowhere"`,
+		},
+		{
+			name: "missing icon",
+			modify: func(pages Pages) {
+				page := pages["Audit Log"]
+				page.Icon = ""
+				pages["Audit Log"] = page
+			},
+			want: `page "Audit Log" has no icon`,
+		},
+		{
+			name: "unregistered api type",
+			modify: func(pages Pages) {
+				pages["Queues"] = Page{Route: "/queues", ApiType: "queues", Icon: "fa-list", HasAssets: true}
+			},
+			want: `page "Queues" shows "queues" assets, which is not a registered asset type`,
+		},
+	}
+
+	for _, test := range tests {
+		pages := AppPages()
+		test.modify(pages)
+
+		problems := CheckAssetTypes(pages)
+		if len(problems) != 1 || !strings.Contains(problems[0], test.want) {
+			t.Errorf("%s: CheckAssetTypes() = %v, want one problem containing %q", test.name, problems, test.want)
+		}
+	}
+}
+
+func TestAssetTypeIDs(t *testing.T) {
+	// Each asset carries the ids of the assets it points at too, the type's own id has to win over them
+	tests := map[string]struct {
+		asset string
+		want  string
+	}{
+		"instances":            {`{"instanceID": "i-1", "imageID": "ami-1", "vpcID": "vpc-1", "subnetID": "subnet-1", "name": "web"}`, "i-1"},
+		"images":               {`{"imageID": "ami-1", "snapshotID": "snap-1", "name": "base"}`, "ami-1"},
+		"keypairs":             {`{"keyName": "deploy", "keyFingerprint": "62:65:01"}`, "deploy"},
+		"launchconfigurations": {`{"name": "web-v2", "imageID": "ami-1", "keyName": "deploy"}`, "web-v2"},
+		"volumes":              {`{"volumeID": "vol-1", "instanceID": "i-1", "snapshotID": "snap-1"}`, "vol-1"},
+		"snapshots":            {`{"snapshotID": "snap-1", "volumeID": "vol-1"}`, "snap-1"},
+		"buckets":              {`{"name": "logs", "region": "us-east-1"}`, "logs"},
+		"simpledbdomains":      {`{"name": "sessions", "region": "us-east-1"}`, "sessions"},
+		"vpcs":                 {`{"vpcID": "vpc-1", "dhcpOptionsID": "dopt-1", "name": "main"}`, "vpc-1"},
+		"subnets":              {`{"subnetID": "subnet-1", "vpcID": "vpc-1", "name": "private-a"}`, "subnet-1"},
+		"securitygroups":       {`{"groupID": "sg-1", "vpcID": "vpc-1", "name": "web"}`, "sg-1"},
+		"addresses":            {`{"allocationID": "eipalloc-1", "associationID": "eipassoc-1", "instanceID": "i-1"}`, "eipalloc-1"},
+		"loadbalancers":        {`{"name": "web-elb", "vpcID": "vpc-1", "dnsName": "web-elb.elb.amazonaws.com"}`, "web-elb"},
+		"certificates":         {`{"name": "www", "arn": "arn:aws:acm:us-east-1:1:certificate/1"}`, "arn:aws:acm:us-east-1:1:certificate/1"},
+		"alarms":               {`{"name": "high-cpu", "arn": "arn:aws:cloudwatch:us-east-1:1:alarm:high-cpu"}`, "high-cpu"},
+		"autoscalegroups":      {`{"name": "web", "launchConfigurationName": "web-v2", "vpcID": "vpc-1"}`, "web"},
+		"scalingpolicies":      {`{"name": "scale-up", "arn": "arn:aws:autoscaling:us-east-1:1:scalingPolicy:1", "autoScaleGroupName": "web"}`, "arn:aws:autoscaling:us-east-1:1:scalingPolicy:1"},
+	}
+
+	for _, assetType := range AssetTypes {
+		test, ok := tests[assetType.ApiType]
+		if !ok {
+			t.Errorf("%s: no id test for this asset type", assetType.ApiType)
+			continue
+		}
+
+		asset, err := gabs.ParseJSON([]byte(test.asset))
+		if err != nil {
+			t.Fatalf("%s: bad test asset: %s", assetType.ApiType, err)
+		}
+		if got := assetType.AssetID(asset); got != test.want {
+			t.Errorf("%s: AssetID(%s) = %q, want %q", assetType.ApiType, test.asset, got, test.want)
+		}
+	}
+}
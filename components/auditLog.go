@@ -0,0 +1,227 @@
+package components
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// AuditLog lists every change made through the dashboard and awsm, with who made it and what changed
+type AuditLog struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (a AuditLog) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "entries": nil, "type": "", "user": "", "search": "", "expanded": ""}
+}
+
+// Implements the ComponentWillMount interface
+func (a AuditLog) ComponentWillMount() {
+	a.getEntries()
+}
+
+func (a AuditLog) getEntries() {
+	a.SetState(gr.State{"querying": true})
+
+	go func() {
+		endpoint := helpers.AuditEndpoint
+		resp, err := helpers.GetAPI(endpoint)
+		if !a.IsMounted() {
+			return
+		}
+		if err != nil {
+			a.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+			return
+		}
+		a.SetState(gr.State{"querying": false, "error": "", "entries": resp})
+	}()
+}
+
+func (a AuditLog) Render() gr.Component {
+
+	state := a.State()
+
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	resp, ok := state.Interface("entries").([]byte)
+	if !ok {
+		return elem
+	}
+
+	entries, err := helpers.ParseAuditEntries(resp)
+	if err != nil {
+		helpers.ErrorElem("Unable to read the audit log: " + err.Error()).Modify(response)
+		return elem
+	}
+
+	// Filters
+	var types, users []string
+	for _, entry := range entries {
+		if entry.Type != "" {
+			types = appendUniqueString(types, entry.Type)
+		}
+		if entry.User != "" {
+			users = appendUniqueString(users, entry.User)
+		}
+	}
+	sort.Strings(types)
+	sort.Strings(users)
+
+	filterForm := el.Form(evt.KeyDown(forms.DisableEnter))
+	el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-3"),
+			forms.SelectOne("Type", "type", types, state.Interface("type"), a.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-3"),
+			forms.SelectOne("User", "user", users, state.Interface("user"), a.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-4"),
+			forms.TextField("Target or Request ID", "search", state.String("search"), a.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			el.Label(gr.Text(" ")),
+			el.Button(
+				evt.Click(a.refreshButton).PreventDefault(),
+				gr.CSS("btn", "btn-default", "btn-block"),
+				el.Italic(gr.CSS("fa", "fa-refresh")),
+				gr.Text(" Refresh"),
+			),
+		),
+	).Modify(filterForm)
+	filterForm.Modify(response)
+
+	// Entries
+	search := strings.ToLower(strings.TrimSpace(state.String("search")))
+	tBody := el.TableBody()
+	shown := 0
+
+	for _, entry := range entries {
+		if t := state.String("type"); t != "" && entry.Type != t {
+			continue
+		}
+		if u := state.String("user"); u != "" && entry.User != u {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(entry.Target+" "+entry.RequestID), search) {
+			continue
+		}
+		shown++
+
+		expanded := state.String("expanded") == entry.ID
+		caret := "fa-caret-right"
+		if expanded {
+			caret = "fa-caret-down"
+		}
+
+		el.TableRow(
+			gr.CSS("audit-entry"),
+			evt.Click(a.toggleEntry(entry.ID)),
+			el.TableData(el.Italic(gr.CSS("fa", caret))),
+			el.TableData(gr.Text(entry.Time)),
+			el.TableData(gr.Text(entry.User)),
+			el.TableData(el.Span(gr.CSS("label", auditActionLabel(entry.Action)), gr.Text(entry.Action))),
+			el.TableData(gr.Text(entry.Type)),
+			el.TableData(gr.Text(entry.Target)),
+			el.TableData(el.Code(gr.Text(entry.RequestID))),
+		).Modify(tBody)
+
+		if expanded {
+			el.TableRow(
+				el.TableData(
+					gr.Prop("colSpan", 7),
+					auditDiff(entry),
+				),
+			).Modify(tBody)
+		}
+	}
+
+	el.Table(
+		gr.CSS("table", "table-condensed", "table-hover", "audit-log"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"", "Time", "User", "Action", "Type", "Target", "Request ID"})...)),
+		tBody,
+	).Modify(response)
+
+	el.Small(gr.Text(fmt.Sprintf("%d of %d entries", shown, len(entries)))).Modify(response)
+
+	return elem
+}
+
+// auditDiff shows the fields an entry changed
+func auditDiff(entry helpers.AuditEntry) *gr.Element {
+	changes := helpers.DiffAudit(entry.Before, entry.After)
+	if len(changes) == 0 {
+		return el.Italic(gr.Text("No field changes recorded"))
+	}
+
+	tBody := el.TableBody()
+	for _, change := range changes {
+		el.TableRow(
+			gr.CSS("audit-"+change.Kind),
+			el.TableData(el.Code(gr.Text(change.Path))),
+			el.TableData(gr.Text(change.Kind)),
+			el.TableData(gr.CSS("audit-before"), gr.Text(change.Before)),
+			el.TableData(gr.CSS("audit-after"), gr.Text(change.After)),
+		).Modify(tBody)
+	}
+
+	return el.Table(
+		gr.CSS("table", "table-condensed", "audit-diff"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Field", "Change", "Before", "After"})...)),
+		tBody,
+	)
+}
+
+func auditActionLabel(action string) string {
+	switch strings.ToUpper(action) {
+	case "DELETE":
+		return "label-danger"
+	case "PUT", "POST":
+		return "label-primary"
+	}
+	return "label-warning"
+}
+
+func (a AuditLog) toggleEntry(id string) func(*gr.Event) {
+	return func(*gr.Event) {
+		if a.State().String("expanded") == id {
+			a.SetState(gr.State{"expanded": ""})
+			return
+		}
+		a.SetState(gr.State{"expanded": id})
+	}
+}
+
+func (a AuditLog) refreshButton(*gr.Event) {
+	a.getEntries()
+}
+
+func (a AuditLog) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	a.SetState(gr.State{key: event.TargetValue().String()})
+}
+
+func (a AuditLog) storeSelect(key string, val interface{}) {
+	if value, ok := val.(map[string]interface{}); ok {
+		a.SetState(gr.State{key: value["value"]})
+	} else {
+		a.SetState(gr.State{key: ""})
+	}
+}
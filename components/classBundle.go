@@ -0,0 +1,175 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+var (
+	// classBundleTypes are the class types that can be exported and imported as a bundle
+	classBundleTypes = ClassTypes()
+
+	classBundleFormats = []string{"yaml", "json"}
+)
+
+// ClassBundle is the document format used to move classes in and out of awsm, keyed by class type and then class name
+type ClassBundle struct {
+	Classes map[string]map[string]map[string]interface{} `json:"classes"`
+}
+
+// ClassBundleEntry is a single class within a bundle
+type ClassBundleEntry struct {
+	Type string
+	Name string
+}
+
+// Key returns the "type/name" key used to track an entry
+func (c ClassBundleEntry) Key() string {
+	return c.Type + "/" + c.Name
+}
+
+// Entries returns every class in the bundle, sorted by type and then name
+func (c ClassBundle) Entries() []ClassBundleEntry {
+	var entries []ClassBundleEntry
+	for classType, classes := range c.Classes {
+		for className := range classes {
+			entries = append(entries, ClassBundleEntry{Type: classType, Name: className})
+		}
+	}
+
+	sort.Sort(classBundleEntries(entries))
+
+	return entries
+}
+
+type classBundleEntries []ClassBundleEntry
+
+func (c classBundleEntries) Len() int      { return len(c) }
+func (c classBundleEntries) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c classBundleEntries) Less(i, j int) bool {
+	if c[i].Type == c[j].Type {
+		return c[i].Name < c[j].Name
+	}
+	return c[i].Type < c[j].Type
+}
+
+// Encode serializes the bundle as either yaml or json
+func (c ClassBundle) Encode(format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(c, "", "  ")
+	case "yaml":
+		return yaml.Marshal(c)
+	}
+	return nil, errors.New("Unknown bundle format: " + format)
+}
+
+// Validate checks that the bundle only contains known class types and usable class names, and that every class
+// reads cleanly into the awsm config struct for its type
+func (c ClassBundle) Validate() error {
+	if len(c.Classes) == 0 {
+		return errors.New("Bundle does not contain any classes!")
+	}
+
+	for _, entry := range c.Entries() {
+		if !isClassBundleType(entry.Type) {
+			return fmt.Errorf("Unknown class type in bundle: %s", entry.Type)
+		}
+
+		class := c.Classes[entry.Type][entry.Name]
+		if entry.Name == "" {
+			return fmt.Errorf("Class with an empty name found in %s", entry.Type)
+		}
+		if strings.ContainsAny(entry.Name, "0123456789") {
+			return fmt.Errorf("Class name %s in %s cannot contain numbers", entry.Name, entry.Type)
+		}
+		if class == nil {
+			return fmt.Errorf("Class %s in %s is empty", entry.Name, entry.Type)
+		}
+		if err := validateClassBody(entry.Type, class); err != nil {
+			return fmt.Errorf("Class %s in %s is invalid: %s", entry.Name, entry.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// validateClassBody decodes a class into the registered config struct for its type, rejecting unknown fields and
+// values of the wrong type
+func validateClassBody(classType string, class map[string]interface{}) error {
+	assetType, ok := FindAssetType(classType)
+	if !ok || !assetType.HasClasses() {
+		return fmt.Errorf("no class type registered for %s", classType)
+	}
+
+	data, err := json.Marshal(class)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(reflect.New(reflect.TypeOf(assetType.Class)).Interface())
+}
+
+// DecodeClassBundle parses a yaml or json class bundle
+func DecodeClassBundle(data []byte) (ClassBundle, error) {
+	var bundle ClassBundle
+
+	// json is valid yaml, so this handles both
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return bundle, fmt.Errorf("Unable to parse bundle: %s", err)
+	}
+
+	if err := json.Unmarshal(jsonData, &bundle); err != nil {
+		return bundle, fmt.Errorf("Unable to parse bundle: %s", err)
+	}
+
+	return bundle, bundle.Validate()
+}
+
+// fetchClassBundle builds a bundle out of every existing class of the given types
+func fetchClassBundle(classTypes []string) (ClassBundle, error) {
+	bundle := ClassBundle{Classes: make(map[string]map[string]map[string]interface{})}
+
+	for _, classType := range classTypes {
+		endpoint := "//localhost:8081/api/classes/" + classType
+		resp, err := helpers.GetAPI(endpoint)
+		if err != nil {
+			return bundle, fmt.Errorf("Error while querying endpoint: %s", endpoint)
+		}
+
+		var classList struct {
+			Success bool                              `json:"success"`
+			Classes map[string]map[string]interface{} `json:"classes"`
+		}
+		if err := json.Unmarshal(resp, &classList); err != nil {
+			return bundle, fmt.Errorf("Unable to parse response from endpoint %s: %s", endpoint, err)
+		}
+
+		if classList.Success && len(classList.Classes) > 0 {
+			bundle.Classes[classType] = classList.Classes
+		}
+	}
+
+	return bundle, nil
+}
+
+func isClassBundleType(classType string) bool {
+	for _, t := range classBundleTypes {
+		if t == classType {
+			return true
+		}
+	}
+	return false
+}
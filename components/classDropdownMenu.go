@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
 	"github.com/bep/gr/el"
 	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
 )
 
 type ClassDropdownMenu struct {
@@ -16,6 +18,15 @@ func (d ClassDropdownMenu) triggerRefresh(event *gr.Event) {
 	d.SetState(gr.State{"nonce": time.Now()})
 }
 
+// Implements the ComponentDidMount interface
+func (d ClassDropdownMenu) ComponentDidMount() {
+	modals := []string{"edit-class", "export-classes"}
+	if d.Props().Interface("canEdit") != nil && d.Props().Bool("canEdit") {
+		modals = append(modals, "new-class", "duplicate-class", "import-classes")
+	}
+	showRequestedModal(modals...)
+}
+
 func (d ClassDropdownMenu) Render() gr.Component {
 
 	//state := d.State()
@@ -38,8 +49,21 @@ func (d ClassDropdownMenu) Render() gr.Component {
 	)
 
 	//el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#new-asset-modal"), gr.Text("New "+pageType))).Modify(dropdownMenu) // New Asset
-	el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#new-class-modal"), gr.Text("New Class"))).Modify(dropdownMenu)   // New Class
-	el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#edit-class-modal"), gr.Text("Edit Class"))).Modify(dropdownMenu) // Edit Classes
+	// Read-only users can view and export classes, but not change them
+	canEdit := props.Interface("canEdit") != nil && props.Bool("canEdit")
+
+	if canEdit {
+		el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#new-class-modal"), gr.Text("New Class"))).Modify(dropdownMenu)             // New Class
+		el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#edit-class-modal"), gr.Text("Edit Class"))).Modify(dropdownMenu)           // Edit Classes
+		el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#duplicate-class-modal"), gr.Text("Duplicate Class"))).Modify(dropdownMenu) // Duplicate Class
+	} else {
+		el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#edit-class-modal"), gr.Text("View Classes"))).Modify(dropdownMenu) // View Classes
+	}
+	el.ListItem(gr.CSS("divider"), attr.Role("separator")).Modify(dropdownMenu)
+	el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#export-classes-modal"), gr.Text("Export Classes"))).Modify(dropdownMenu) // Export Classes
+	if canEdit {
+		el.ListItem(el.Anchor(gr.Data("toggle", "modal"), gr.Data("target", "#import-classes-modal"), gr.Text("Import Classes"))).Modify(dropdownMenu) // Import Classes
+	}
 
 	// New Asset
 	// TODO
@@ -50,15 +74,40 @@ func (d ClassDropdownMenu) Render() gr.Component {
 	*/
 
 	// New Class
-	gr.New(&Modal{}).CreateElement(gr.Props{"id": "new-class-modal", "title": "New " + pageType + " Class"},
-		gr.New(&NewClass{}).CreateElement(gr.Props{"apiType": apiType}),
-	).Modify(dropdown)
+	if canEdit {
+		gr.New(&Modal{}).CreateElement(gr.Props{"id": "new-class-modal", "title": "New " + pageType + " Class"},
+			gr.New(&NewClass{}).CreateElement(gr.Props{"apiType": apiType}),
+		).Modify(dropdown)
+	}
 
 	// Edit Class
-	gr.New(&Modal{}).CreateElement(gr.Props{"id": "edit-class-modal", "title": "Edit " + pageType + " Classes"},
-		gr.New(&EditClass{}).CreateElement(gr.Props{"apiType": apiType}),
+	editTitle := "Edit " + pageType + " Classes"
+	if !canEdit {
+		editTitle = pageType + " Classes"
+	}
+	gr.New(&Modal{}).CreateElement(gr.Props{"id": "edit-class-modal", "title": editTitle},
+		gr.New(&EditClass{}).CreateElement(gr.Props{"apiType": apiType, "className": helpers.QueryParam("class")}),
 	).Modify(dropdown)
 
+	// Duplicate Class
+	if canEdit {
+		gr.New(&Modal{}).CreateElement(gr.Props{"id": "duplicate-class-modal", "title": "Duplicate " + pageType + " Class"},
+			gr.New(&DuplicateClass{}).CreateElement(gr.Props{"apiType": apiType}),
+		).Modify(dropdown)
+	}
+
+	// Export Classes
+	gr.New(&Modal{}).CreateElement(gr.Props{"id": "export-classes-modal", "title": "Export Classes"},
+		gr.New(&ExportClasses{}).CreateElement(gr.Props{"apiType": apiType}),
+	).Modify(dropdown)
+
+	// Import Classes
+	if canEdit {
+		gr.New(&Modal{}).CreateElement(gr.Props{"id": "import-classes-modal", "title": "Import Classes"},
+			gr.New(&ImportClasses{}).CreateElement(gr.Props{"apiType": apiType}),
+		).Modify(dropdown)
+	}
+
 	dropdownMenu.Modify(dropdown)
 
 	return dropdown
@@ -0,0 +1,276 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+const (
+	graphColumnWidth = 230
+	graphRowHeight   = 50
+	graphNodeWidth   = 180
+	graphNodeHeight  = 36
+	graphPadding     = 10
+)
+
+type ClassGraph struct {
+	*gr.This
+}
+
+// graphNode is a class placed on the graph
+type graphNode struct {
+	Type string
+	Name string
+	X    int
+	Y    int
+}
+
+// Implements the StateInitializer interface
+func (c ClassGraph) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "bundle": nil, "selectedType": "", "selectedName": "", "classData": nil}
+}
+
+// Implements the ComponentWillMount interface
+func (c ClassGraph) ComponentWillMount() {
+	c.getClasses()
+}
+
+func (c ClassGraph) getClasses() {
+	c.SetState(gr.State{"querying": true})
+
+	go func() {
+		bundle, err := fetchClassBundle(classBundleTypes)
+		if !c.IsMounted() {
+			return
+		}
+		if err != nil {
+			c.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		bundleJson, _ := json.Marshal(bundle)
+		c.SetState(gr.State{"querying": false, "error": "", "bundle": bundleJson})
+	}()
+}
+
+func (c ClassGraph) Render() gr.Component {
+
+	state := c.State()
+
+	// Graph placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return elem
+	}
+
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	if len(bundle.Entries()) < 1 {
+		gr.Text("Nothing here!").Modify(response)
+		return elem
+	}
+
+	c.BuildGraph(bundle).Modify(response)
+
+	// Class editor
+	if classData, ok := state.Interface("classData").([]byte); ok {
+		classForm, classJson := EditClassFormBuilder(classData)
+		if classForm != nil {
+			el.Div(
+				gr.CSS("class-graph-editor"),
+				el.HorizontalRule(),
+				classForm.CreateElement(gr.Props{
+					"className":     classJson.S("className").Data().(string),
+					"class":         classJson.S("class").Bytes(),
+					"backButton":    c.closeEditor,
+					"apiType":       state.String("selectedType"),
+					"hasDelete":     true,
+					"hideAllModals": c.closeEditor,
+					"editClass":     true,
+				}),
+			).Modify(response)
+		}
+	}
+
+	return elem
+}
+
+// BuildGraph lays out every class in columns by type and draws the references between them
+func (c ClassGraph) BuildGraph(bundle ClassBundle) *gr.Element {
+
+	state := c.State()
+	selectedKey := ClassBundleEntry{Type: state.String("selectedType"), Name: state.String("selectedName")}.Key()
+
+	// Place the nodes
+	layers := classTypeLayers()
+	columns := make(map[int][]ClassBundleEntry)
+	maxLayer := 0
+	for _, entry := range bundle.Entries() {
+		layer := layers[entry.Type]
+		columns[layer] = append(columns[layer], entry)
+		if layer > maxLayer {
+			maxLayer = layer
+		}
+	}
+
+	nodes := make(map[string]graphNode)
+	maxRows := 0
+	for layer := 0; layer <= maxLayer; layer++ {
+		for row, entry := range columns[layer] {
+			nodes[entry.Key()] = graphNode{
+				Type: entry.Type,
+				Name: entry.Name,
+				X:    graphPadding + layer*graphColumnWidth,
+				Y:    graphPadding + row*graphRowHeight,
+			}
+		}
+		if len(columns[layer]) > maxRows {
+			maxRows = len(columns[layer])
+		}
+	}
+
+	width := graphPadding*2 + maxLayer*graphColumnWidth + graphNodeWidth
+	height := graphPadding*2 + maxRows*graphRowHeight
+
+	svg := gr.Elem("svg",
+		gr.CSS("class-graph"),
+		gr.Prop("width", width),
+		gr.Prop("height", height),
+		gr.Prop("viewBox", fmt.Sprintf("0 0 %d %d", width, height)),
+	)
+
+	// Edges first so the nodes are drawn over them
+	var missing []helpers.ClassLink
+	for _, link := range classLinks(bundle) {
+		from, fromOk := nodes[ClassBundleEntry{Type: link.FromType, Name: link.FromName}.Key()]
+		to, toOk := nodes[ClassBundleEntry{Type: link.ToType, Name: link.ToName}.Key()]
+		if !fromOk || !toOk {
+			missing = append(missing, link)
+			continue
+		}
+
+		edgeCSS := gr.CSS("class-graph-edge")
+		if from.key() == selectedKey || to.key() == selectedKey {
+			edgeCSS = gr.CSS("class-graph-edge", "active")
+		}
+
+		x1, y1 := from.X+graphNodeWidth, from.Y+graphNodeHeight/2
+		x2, y2 := to.X, to.Y+graphNodeHeight/2
+		mid := (x1 + x2) / 2
+
+		gr.Elem("path",
+			edgeCSS,
+			gr.Prop("d", fmt.Sprintf("M %d %d C %d %d, %d %d, %d %d", x1, y1, mid, y1, mid, y2, x2, y2)),
+			gr.Elem("title", gr.Text(link.FromName+" "+link.Field+" -> "+link.ToName)),
+		).Modify(svg)
+	}
+
+	keys := make([]string, 0, len(nodes))
+	for key := range nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := nodes[key]
+
+		nodeCSS := gr.CSS("class-graph-node", "class-graph-node-"+node.Type)
+		if key == selectedKey {
+			nodeCSS = gr.CSS("class-graph-node", "class-graph-node-"+node.Type, "active")
+		}
+
+		gr.Elem("g",
+			nodeCSS,
+			evt.Click(c.selectNode(node.Type, node.Name)),
+			gr.Elem("rect",
+				gr.Prop("x", node.X),
+				gr.Prop("y", node.Y),
+				gr.Prop("width", graphNodeWidth),
+				gr.Prop("height", graphNodeHeight),
+			),
+			gr.Elem("text",
+				gr.CSS("class-graph-name"),
+				gr.Prop("x", node.X+8),
+				gr.Prop("y", node.Y+15),
+				gr.Text(node.Name),
+			),
+			gr.Elem("text",
+				gr.CSS("class-graph-type"),
+				gr.Prop("x", node.X+8),
+				gr.Prop("y", node.Y+29),
+				gr.Text(node.Type),
+			),
+		).Modify(svg)
+	}
+
+	graph := el.Div(
+		gr.CSS("class-graph-wrapper"),
+		svg,
+	)
+
+	// References to classes that don't exist
+	if len(missing) > 0 {
+		missingList := el.UnorderedList()
+		for _, link := range missing {
+			el.ListItem(
+				gr.Text(fmt.Sprintf("%s %s references missing %s class %s via %s", link.FromType, link.FromName, link.ToType, link.ToName, link.Field)),
+			).Modify(missingList)
+		}
+
+		el.Div(
+			gr.CSS("alert", "alert-warning"),
+			el.Strong(gr.Text("Missing references")),
+			missingList,
+		).Modify(graph)
+	}
+
+	return graph
+}
+
+func (n graphNode) key() string {
+	return ClassBundleEntry{Type: n.Type, Name: n.Name}.Key()
+}
+
+func (c ClassGraph) selectNode(classType, className string) func(*gr.Event) {
+	return func(*gr.Event) {
+		c.SetState(gr.State{"selectedType": classType, "selectedName": className, "classData": nil})
+
+		go func() {
+			endpoint := "//localhost:8081/api/classes/" + classType + "/name/" + className
+			resp, err := helpers.GetAPI(endpoint)
+			if !c.IsMounted() {
+				return
+			}
+			if err != nil {
+				c.SetState(gr.State{"error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+			c.SetState(gr.State{"error": "", "classData": resp})
+		}()
+	}
+}
+
+func (c ClassGraph) closeEditor() {
+	c.SetState(gr.State{"selectedType": "", "selectedName": "", "classData": nil})
+	c.getClasses()
+}
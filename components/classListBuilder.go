@@ -1,8 +1,6 @@
 package components
 
 import (
-	"encoding/json"
-
 	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
 	"github.com/bep/gr/attr"
@@ -29,134 +27,16 @@ func ClassListBuilder(cl interface{}, onClick func(string)) *gr.Element {
 		),
 	)
 
-	switch classType {
-
-	case "alarms":
-		var cType config.AlarmClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "instances":
-		var cType config.InstanceClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "volumes":
-		var cType config.VolumeClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "images":
-		var cType config.ImageClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "keypairs":
-		var cType config.KeyPairClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "snapshots":
-		var cType config.SnapshotClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "vpcs":
-		var cType config.VpcClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "subnets":
-		var cType config.SubnetClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "securitygroups":
-		var cType config.SecurityGroupClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	//case "addresses":
-	// TODO ?
-
-	case "launchconfigurations":
-		var cType config.LaunchConfigurationClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "autoscalegroups":
-		var cType config.AutoscaleGroupClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "loadbalancers":
-		var cType config.LoadBalancerClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	case "scalingpolicies":
-		var cType config.ScalingPolicyClass
-		for className, class := range classes {
-			cJson := class.Bytes()
-			json.Unmarshal(cJson, &cType)
-			keys, values := config.ExtractAwsmClass(cType)
-			buildClassButton(className, keys, values, classListGroup, onClick)
-		}
-
-	//case "simpledbdomains":
-	// TODO ?
-
-	default:
-		println("Class Type not found in ClassListBuilder switch:")
+	t, ok := FindAssetType(classType)
+	if !ok || !t.HasClasses() {
+		println("Class Type not found in AssetTypes:")
 		println(classType)
+		return classListGroup
+	}
+
+	for className, class := range classes {
+		keys, values := config.ExtractAwsmClass(decodeAs(t.Class, class.Bytes()))
+		buildClassButton(className, keys, values, classListGroup, onClick)
 	}
 
 	return classListGroup
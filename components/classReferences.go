@@ -0,0 +1,54 @@
+package components
+
+import (
+	"sort"
+
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// classLinks resolves every reference between the classes in a bundle
+func classLinks(bundle ClassBundle) []helpers.ClassLink {
+	var links []helpers.ClassLink
+
+	for _, ref := range helpers.ClassReferences {
+		classes := bundle.Classes[ref.FromType]
+
+		names := make([]string, 0, len(classes))
+		for name := range classes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for _, target := range helpers.ReferencedNames(classes[name], ref.Field) {
+				links = append(links, helpers.ClassLink{
+					FromType: ref.FromType,
+					FromName: name,
+					Field:    ref.Field,
+					ToType:   ref.ToType,
+					ToName:   target,
+				})
+			}
+		}
+	}
+
+	return links
+}
+
+// classTypeLayers orders the class types so every type sits in a later layer than the types that reference it
+func classTypeLayers() map[string]int {
+	layers := make(map[string]int)
+	for _, classType := range classBundleTypes {
+		layers[classType] = 0
+	}
+
+	for range classBundleTypes {
+		for _, ref := range helpers.ClassReferences {
+			if layers[ref.ToType] <= layers[ref.FromType] {
+				layers[ref.ToType] = layers[ref.FromType] + 1
+			}
+		}
+	}
+
+	return layers
+}
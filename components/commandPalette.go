@@ -0,0 +1,364 @@
+package components
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+const paletteMaxResults = 12
+
+// paletteKeyListener is the document level Ctrl-K / Cmd-K listener, kept so it can be removed on unmount
+var paletteKeyListener *js.Object
+
+// CommandPalette fuzzy-searches pages, actions, classes and assets, opened with Ctrl-K or Cmd-K
+type CommandPalette struct {
+	*gr.This
+	Pages
+}
+
+// paletteItem is one thing the palette can jump to
+type paletteItem struct {
+	Kind   string `json:"kind"` // Page, Action, Class or Asset
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Path   string `json:"path"`
+}
+
+type paletteMatch struct {
+	paletteItem
+	score int
+}
+
+type paletteMatches []paletteMatch
+
+func (p paletteMatches) Len() int      { return len(p) }
+func (p paletteMatches) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p paletteMatches) Less(i, j int) bool {
+	if p[i].score != p[j].score {
+		return p[i].score > p[j].score
+	}
+	return p[i].Title < p[j].Title
+}
+
+// Implements the StateInitializer interface
+func (c CommandPalette) GetInitialState() gr.State {
+	return gr.State{"open": false, "query": "", "selected": 0, "loading": false, "index": nil}
+}
+
+// Implements the ComponentDidMount interface
+func (c CommandPalette) ComponentDidMount() {
+	paletteKeyListener = js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		event := args[0]
+		if (event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool()) && strings.ToLower(event.Get("key").String()) == "k" {
+			event.Call("preventDefault")
+			if c.State().Bool("open") {
+				c.close()
+			} else {
+				c.open()
+			}
+		}
+		return nil
+	})
+	js.Global.Get("document").Call("addEventListener", "keydown", paletteKeyListener)
+}
+
+// Implements the ComponentWillUnmount interface
+func (c CommandPalette) ComponentWillUnmount() {
+	if paletteKeyListener != nil {
+		js.Global.Get("document").Call("removeEventListener", "keydown", paletteKeyListener)
+		paletteKeyListener = nil
+	}
+}
+
+func (c CommandPalette) open() {
+	c.SetState(gr.State{"open": true, "query": "", "selected": 0})
+
+	// Classes and assets are indexed the first time the palette opens
+	if c.State().Interface("index") != nil || c.State().Bool("loading") {
+		return
+	}
+	c.SetState(gr.State{"loading": true})
+	go func() {
+		index := c.buildIndex()
+		if !c.IsMounted() {
+			return
+		}
+		indexJson, _ := json.Marshal(index)
+		c.SetState(gr.State{"loading": false, "index": indexJson})
+	}()
+}
+
+func (c CommandPalette) close() {
+	c.SetState(gr.State{"open": false, "query": "", "selected": 0})
+}
+
+func (c CommandPalette) Render() gr.Component {
+
+	state := c.State()
+
+	if !state.Bool("open") {
+		return el.Div()
+	}
+
+	matches := c.matches()
+	selected := state.Int("selected")
+
+	results := el.UnorderedList(gr.CSS("list-unstyled", "command-palette-results"))
+	for i, match := range matches {
+		item := el.ListItem(
+			gr.CSS("command-palette-item"),
+			evt.Click(c.selectButton(match.Path)).PreventDefault(),
+			el.Span(gr.CSS("label", paletteKindLabel(match.Kind)), gr.Text(match.Kind)),
+			gr.Text(" "),
+			el.Strong(gr.Text(match.Title)),
+			el.Small(gr.CSS("text-muted"), gr.Text(" "+match.Detail)),
+		)
+		if i == selected {
+			gr.CSS("command-palette-item", "active").Modify(item)
+		}
+		item.Modify(results)
+	}
+	if len(matches) == 0 {
+		el.ListItem(gr.CSS("command-palette-empty"), gr.Text("No matches")).Modify(results)
+	}
+
+	status := "Type to search pages, actions, classes and assets"
+	if state.Bool("loading") {
+		status = "Indexing classes and assets..."
+	}
+
+	return el.Div(
+		gr.CSS("command-palette-backdrop"),
+		evt.Click(c.backdropClick),
+		el.Div(
+			gr.CSS("command-palette"),
+			el.Input(
+				attr.Type("text"),
+				attr.ClassName("form-control input-lg"),
+				attr.Placeholder("Search..."),
+				attr.Value(state.String("query")),
+				gr.Prop("autoFocus", true),
+				evt.Change(c.storeQuery),
+				evt.KeyDown(c.keyDown),
+			),
+			results,
+			el.Small(gr.CSS("text-muted"), gr.Text(status+" - ↑↓ to move, Enter to go, Esc to close")),
+		),
+	)
+}
+
+// matches are the best scoring items for the current query
+func (c CommandPalette) matches() []paletteMatch {
+	query := c.State().String("query")
+
+	items := c.staticItems()
+	if indexJson, ok := c.State().Interface("index").([]byte); ok {
+		var index []paletteItem
+		json.Unmarshal(indexJson, &index)
+		items = append(items, index...)
+	}
+
+	var matches paletteMatches
+	for _, item := range items {
+		// Without a query only pages and actions are listed
+		if strings.TrimSpace(query) == "" && item.Kind != "Page" && item.Kind != "Action" {
+			continue
+		}
+		titleScore, titleOk := helpers.FuzzyMatch(query, item.Title)
+		detailScore, detailOk := helpers.FuzzyMatch(query, item.Kind+" "+item.Detail)
+		switch {
+		case titleOk:
+			matches = append(matches, paletteMatch{item, titleScore*2 + detailScore})
+		case detailOk:
+			matches = append(matches, paletteMatch{item, detailScore})
+		}
+	}
+	sort.Sort(matches)
+
+	if len(matches) > paletteMaxResults {
+		matches = matches[:paletteMaxResults]
+	}
+	return matches
+}
+
+// staticItems are the pages and actions, which need no api calls
+func (c CommandPalette) staticItems() []paletteItem {
+	var items []paletteItem
+	canEdit := helpers.CanEdit()
+
+	for name, page := range c.Pages {
+		if !page.Linkable() {
+			continue
+		}
+		items = append(items, paletteItem{Kind: "Page", Title: name, Detail: page.Route, Path: page.Route})
+
+		if !canEdit {
+			continue
+		}
+		if page.HasClasses {
+			items = append(items, paletteItem{Kind: "Action", Title: "New " + page.Type + " Class", Detail: name, Path: page.Route + "?modal=new-class"})
+		}
+		if page.HasWidgets {
+			items = append(items, paletteItem{Kind: "Action", Title: "New Widget", Detail: name, Path: page.Route + "?modal=new-widget"})
+		}
+	}
+
+	return items
+}
+
+// buildIndex fetches every class and asset the palette can jump to
+func (c CommandPalette) buildIndex() []paletteItem {
+	var items []paletteItem
+
+	var classTypes []string
+	classPages := make(map[string]Page)
+	for _, page := range c.Pages {
+		if page.HasClasses {
+			classTypes = append(classTypes, page.ApiType)
+			classPages[page.ApiType] = page
+		}
+	}
+	sort.Strings(classTypes)
+
+	// Classes
+	bundle, err := fetchClassBundle(classTypes)
+	if err != nil {
+		println(err.Error())
+	}
+	for _, entry := range bundle.Entries() {
+		page := classPages[entry.Type]
+		items = append(items, paletteItem{
+			Kind:   "Class",
+			Title:  entry.Name,
+			Detail: page.Type + " Class",
+			Path:   page.Route + "?modal=edit-class&class=" + url.QueryEscape(entry.Name),
+		})
+	}
+
+	// Assets
+	for _, page := range c.Pages {
+		if !page.HasAssets {
+			continue
+		}
+
+		endpoint := "//localhost:8081/api/assets/" + page.ApiType
+		resp, err := helpers.GetAPI(endpoint)
+		if err != nil {
+			println("Error while querying endpoint: " + endpoint)
+			continue
+		}
+
+		assetType, _ := FindAssetType(page.ApiType)
+		jsonParsed, _ := gabs.ParseJSON(resp)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			id, name, tags := paletteAssetFields(assetType, asset)
+			if id == "" && name == "" {
+				continue
+			}
+
+			title := name
+			if title == "" {
+				title = id
+			}
+			items = append(items, paletteItem{
+				Kind:   "Asset",
+				Title:  title,
+				Detail: strings.TrimSpace(page.Type + " " + id + " " + strings.Join(tags, " ")),
+				Path:   paletteAssetPath(page, assetType, asset),
+			})
+		}
+	}
+
+	return items
+}
+
+// paletteAssetFields pulls the id, name and tags out of an asset of the type
+func paletteAssetFields(assetType AssetType, asset *gabs.Container) (string, string, []string) {
+	var tags []string
+	for key, value := range helpers.AssetTags(asset) {
+		tags = append(tags, key+"="+value)
+	}
+	sort.Strings(tags)
+
+	return assetType.AssetID(asset), firstString(asset, []string{"name", "Name"}), tags
+}
+
+// paletteAssetPath links to the asset's detail page when its type has one, or else to its row in the asset table
+func paletteAssetPath(page Page, assetType AssetType, asset *gabs.Container) string {
+	if assetType.Detail != nil {
+		return assetType.Detail(asset)
+	}
+	if id := assetType.AssetID(asset); id != "" {
+		return page.Route + "?asset=" + url.QueryEscape(id)
+	}
+	return page.Route
+}
+
+func paletteKindLabel(kind string) string {
+	switch kind {
+	case "Action":
+		return "label-success"
+	case "Class":
+		return "label-primary"
+	case "Asset":
+		return "label-info"
+	}
+	return "label-default"
+}
+
+func (c CommandPalette) jump(path string) {
+	c.close()
+	helpers.Navigate(path)
+}
+
+func (c CommandPalette) selectButton(path string) func(*gr.Event) {
+	return func(*gr.Event) {
+		c.jump(path)
+	}
+}
+
+func (c CommandPalette) backdropClick(event *gr.Event) {
+	// Only clicks on the backdrop itself, not the palette inside it
+	if event.Target() == event.Get("currentTarget") {
+		c.close()
+	}
+}
+
+func (c CommandPalette) keyDown(event *gr.Event) {
+	selected := c.State().Int("selected")
+
+	switch event.Get("key").String() {
+	case "ArrowDown":
+		event.Object.Call("preventDefault")
+		if selected < len(c.matches())-1 {
+			c.SetState(gr.State{"selected": selected + 1})
+		}
+	case "ArrowUp":
+		event.Object.Call("preventDefault")
+		if selected > 0 {
+			c.SetState(gr.State{"selected": selected - 1})
+		}
+	case "Enter":
+		event.Object.Call("preventDefault")
+		if matches := c.matches(); selected < len(matches) {
+			c.jump(matches[selected].Path)
+		}
+	case "Escape":
+		c.close()
+	}
+}
+
+func (c CommandPalette) storeQuery(event *gr.Event) {
+	c.SetState(gr.State{"query": event.TargetValue().String(), "selected": 0})
+}
@@ -0,0 +1,37 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/Jeffail/gabs"
+)
+
+func TestPaletteAssetPath(t *testing.T) {
+	tests := []struct {
+		apiType string
+		asset   string
+		want    string
+	}{
+		{"vpcs", `{"vpcID": "vpc-1", "name": "main"}`, "/vpcs/vpc-1"},
+		{"subnets", `{"subnetID": "subnet-1", "vpcID": "vpc-1"}`, "/subnets?asset=subnet-1"},
+		{"instances", `{"instanceID": "i-1", "imageID": "ami-1"}`, "/instances?asset=i-1"},
+		{"autoscalegroups", `{"name": "web & api", "launchConfigurationName": "web-v2"}`, "/autoscalegroups?asset=web+%26+api"},
+		{"addresses", `{"publicIP": "203.0.113.7"}`, "/addresses"},
+	}
+
+	for _, test := range tests {
+		assetType, ok := FindAssetType(test.apiType)
+		if !ok {
+			t.Fatalf("%s: not a registered asset type", test.apiType)
+		}
+		asset, err := gabs.ParseJSON([]byte(test.asset))
+		if err != nil {
+			t.Fatalf("%s: bad test asset: %s", test.apiType, err)
+		}
+
+		page := assetType.Page(0)
+		if got := paletteAssetPath(page, assetType, asset); got != test.want {
+			t.Errorf("paletteAssetPath(%s, %s) = %q, want %q", test.apiType, test.asset, got, test.want)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package components
 
 import (
+	"path"
+
 	"github.com/bep/gr"
 	"github.com/bep/gr/el"
+	"github.com/murdinc/awsmDashboard/helpers"
 )
 
 type Content struct {
@@ -22,10 +25,11 @@ func (c Content) Render() gr.Component {
 			gr.Text(c.Props().String("activePage")+" "),
 		),
 	)
+	canEdit := helpers.CanEdit()
 	if c.Page.HasClasses {
-		gr.New(&ClassDropdownMenu{}).CreateElement(gr.Props{"type": c.Page.Type, "apiType": c.Page.ApiType}).Modify(header)
+		gr.New(&ClassDropdownMenu{}).CreateElement(gr.Props{"type": c.Page.Type, "apiType": c.Page.ApiType, "canEdit": canEdit}).Modify(header)
 	}
-	if c.Page.HasWidgets {
+	if c.Page.HasWidgets && canEdit {
 		gr.New(&WidgetDropdownMenu{}).CreateElement(gr.Props{"type": c.Page.Type, "apiType": c.Page.ApiType}).Modify(header)
 	}
 	header.Modify(resp)
@@ -36,6 +40,54 @@ func (c Content) Render() gr.Component {
 		return resp
 	}
 
+	// Class Graph
+	if c.Page.ApiType == "classgraph" {
+		gr.New(&ClassGraph{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// Exposure Report
+	if c.Page.ApiType == "exposure" {
+		gr.New(&ExposureReport{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// Scaling Simulator
+	if c.Page.ApiType == "scalingsimulator" {
+		gr.New(&ScalingSimulator{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// Rollouts
+	if c.Page.ApiType == "rollouts" {
+		gr.New(&RolloutPanel{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// Retention
+	if c.Page.ApiType == "retention" {
+		gr.New(&RetentionOverview{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// Audit Log
+	if c.Page.ApiType == "audit" {
+		gr.New(&AuditLog{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
+	// VPC Topology
+	if c.Page.ApiType == "vpctopology" {
+		gr.New(&VpcTopology{}).CreateElement(gr.Props{"vpcId": path.Base(helpers.CurrentPath())}).Modify(resp)
+		return resp
+	}
+
+	// Sign In
+	if c.Page.ApiType == "login" {
+		gr.New(&Login{}).CreateElement(gr.Props{}).Modify(resp)
+		return resp
+	}
+
 	// Asset Table
 	gr.New(&AssetTable{}).CreateElement(gr.Props{"apiType": c.Page.ApiType}).Modify(resp)
 
@@ -0,0 +1,208 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+type DuplicateClass struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (d DuplicateClass) GetInitialState() gr.State {
+	return gr.State{"step": 1, "selectedClass": "", "className": "", "querying": false, "error": "", "classData": nil}
+}
+
+func (d DuplicateClass) ComponentWillMount() {
+	d.getClassList()
+}
+
+func (d DuplicateClass) Render() gr.Component {
+
+	state := d.State()
+	props := d.Props()
+
+	// Response placeholder
+	response := el.Div()
+
+	if state.Bool("querying") && state.Int("step") == 1 {
+		gr.Text("Loading...").Modify(response)
+		return response
+	}
+
+	// Print any alerts
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	if state.Int("step") == 1 {
+
+		// STEP 1
+
+		if classes := state.Interface("classList"); classes != nil {
+			classList := ClassListBuilder(classes, d.selectClass) // Build the class list
+			classList.Modify(response)
+		} else {
+
+			helpers.ErrorElem("No existing " + props.String("apiType") + " classes found!").Modify(response)
+
+			buttons := el.Div(
+				gr.CSS("btn-toolbar"),
+			)
+
+			// Done
+			el.Button(
+				evt.Click(d.closeButton).PreventDefault(),
+				gr.CSS("btn", "btn-primary"),
+				gr.Text("Done"),
+			).Modify(buttons)
+
+			buttons.Modify(response)
+		}
+
+	} else if state.Int("step") == 2 {
+
+		// STEP 2
+
+		el.Paragraph(
+			gr.Text("Duplicating "),
+			el.Strong(gr.Text(state.String("selectedClass"))),
+		).Modify(response)
+
+		newClassForm := el.Form(evt.KeyDown(forms.CaptureEnter(d.stepTwoNext)))
+
+		forms.TextField("New Name", "className", state.String("className"), d.storeValue).Modify(newClassForm)
+
+		buttons := el.Div(
+			gr.CSS("btn-toolbar"),
+		)
+
+		// Back
+		el.Button(
+			evt.Click(d.stepTwoBack).PreventDefault(),
+			gr.CSS("btn", "btn-secondary"),
+			gr.Text("Back"),
+		).Modify(buttons)
+
+		// Next
+		el.Button(
+			evt.Click(d.stepTwoNext).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Next"),
+		).Modify(buttons)
+
+		if state.Bool("querying") {
+			attr.Disabled("").Modify(newClassForm)
+		}
+
+		newClassForm.Modify(response)
+		buttons.Modify(response)
+
+	} else if state.Int("step") == 3 {
+
+		// STEP 3
+
+		classForm, classJson := EditClassFormBuilder(state.Interface("classData").([]byte))
+
+		classForm.CreateElement(gr.Props{
+			"className":     state.String("className"),
+			"class":         classJson.S("class").Bytes(),
+			"backButton":    d.stepThreeBack,
+			"apiType":       props.String("apiType"),
+			"hideAllModals": hideAllModals,
+			"newClass":      true,
+		}).Modify(response)
+	}
+
+	return response
+}
+
+func (d DuplicateClass) getClassList() {
+	go func() {
+		if apiType := d.Props().String("apiType"); apiType != "" {
+			d.SetState(gr.State{"querying": true})
+			endpoint := "//localhost:8081/api/classes/" + apiType
+			resp, err := helpers.GetAPI(endpoint)
+			if !d.IsMounted() {
+				return
+			}
+			if err != nil {
+				d.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+
+			respParsed, _ := gabs.ParseJSON(resp)
+
+			success, ok := respParsed.S("success").Data().(bool)
+			if !ok || !success {
+				println("no existing " + d.Props().String("apiType") + " classes found")
+				d.SetState(gr.State{"querying": false})
+				return
+			}
+
+			d.SetState(gr.State{"querying": false, "classList": resp})
+		}
+	}()
+}
+
+func (d *DuplicateClass) selectClass(name string) {
+	d.SetState(gr.State{"querying": true})
+	go func() {
+		if apiType := d.Props().String("apiType"); apiType != "" {
+			endpoint := "//localhost:8081/api/classes/" + apiType + "/name/" + name
+			resp, err := helpers.GetAPI(endpoint)
+			if !d.IsMounted() {
+				return
+			}
+			if err != nil {
+				d.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+			d.SetState(gr.State{"classData": resp})
+		}
+		d.SetState(gr.State{"querying": false, "error": "", "step": 2, "selectedClass": name, "className": name + "-copy"})
+	}()
+}
+
+func (d DuplicateClass) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	d.SetState(gr.State{key: event.TargetValue()})
+}
+
+func (d DuplicateClass) closeButton(*gr.Event) {
+	hideAllModals()
+}
+
+func (d DuplicateClass) stepTwoNext(*gr.Event) {
+	if !helpers.CanEdit() {
+		d.SetState(gr.State{"error": helpers.ErrReadOnly.Error()})
+		return
+	}
+
+	d.SetState(gr.State{"querying": true, "error": ""})
+
+	go func(className string) {
+
+		if errStr := checkNewClassName(d.Props().String("apiType"), className); errStr != "" {
+			d.SetState(gr.State{"error": errStr, "querying": false})
+			return
+		}
+
+		d.SetState(gr.State{"error": "", "querying": false, "step": 3})
+	}(d.State().String("className"))
+}
+
+func (d DuplicateClass) stepTwoBack(*gr.Event) {
+	d.getClassList()
+	d.SetState(gr.State{"step": 1, "error": "", "classData": nil})
+}
+
+func (d DuplicateClass) stepThreeBack() {
+	d.SetState(gr.State{"step": 2})
+}
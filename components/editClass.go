@@ -20,6 +20,11 @@ func (e EditClass) GetInitialState() gr.State {
 }
 
 func (e EditClass) ComponentWillMount() {
+	// Go straight to the class when one is given, like from the command palette
+	if className := e.Props().String("className"); className != "" {
+		e.selectClass(className)
+		return
+	}
 	e.getClassList()
 }
 
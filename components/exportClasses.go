@@ -0,0 +1,279 @@
+package components
+
+import (
+	"encoding/json"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+type ExportClasses struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (e ExportClasses) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "success": "", "scope": e.Props().String("apiType"), "format": "yaml", "bundle": nil, "selected": []string{}}
+}
+
+// Implements the ComponentWillMount interface
+func (e ExportClasses) ComponentWillMount() {
+	e.getClasses(e.Props().String("apiType"))
+}
+
+func (e ExportClasses) Render() gr.Component {
+
+	state := e.State()
+	props := e.Props()
+
+	// Response placeholder
+	response := el.Div()
+
+	// Print any alerts
+	helpers.ErrorElem(state.String("error")).Modify(response)
+	helpers.SuccessElem(state.String("success")).Modify(response)
+
+	exportForm := el.Form(evt.KeyDown(forms.DisableEnter))
+
+	el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-6"),
+			forms.SelectOne("Scope", "scope", []string{props.String("apiType"), "everything"}, state.Interface("scope"), e.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-6"),
+			forms.SelectOne("Format", "format", classBundleFormats, state.Interface("format"), e.storeSelect),
+		),
+	).Modify(exportForm)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(exportForm)
+
+	} else if bundleJson, ok := state.Interface("bundle").([]byte); ok {
+
+		var bundle ClassBundle
+		json.Unmarshal(bundleJson, &bundle)
+		selected := stringSet(state.Interface("selected"))
+
+		el.Div(
+			el.Header4(
+				gr.Text("Classes"),
+				el.Div(
+					gr.CSS("btn-group", "pull-right"),
+					el.Button(
+						evt.Click(e.selectAll).PreventDefault(),
+						gr.CSS("btn", "btn-default", "btn-xs"),
+						gr.Text("All"),
+					),
+					el.Button(
+						evt.Click(e.selectNone).PreventDefault(),
+						gr.CSS("btn", "btn-default", "btn-xs"),
+						gr.Text("None"),
+					),
+				),
+			),
+			el.HorizontalRule(nil),
+		).Modify(exportForm)
+
+		entries := bundle.Entries()
+		if len(entries) < 1 {
+			gr.Text("Nothing here!").Modify(exportForm)
+		}
+
+		classList := el.Div(gr.CSS("class-bundle-list"))
+		for _, entry := range entries {
+			var checked gr.Modifier
+			if selected[entry.Key()] {
+				checked = attr.Checked(true)
+			}
+
+			el.Div(
+				gr.CSS("checkbox"),
+				el.Label(
+					el.Input(
+						attr.Value(""), // to stop the warning about a uncontrolled components
+						attr.Type("checkbox"),
+						attr.Name(entry.Key()),
+						checked,
+						evt.Change(e.toggleClass).StopPropagation(),
+					),
+					gr.Text(entry.Type+" / "),
+					el.Strong(gr.Text(entry.Name)),
+				),
+			).Modify(classList)
+		}
+		classList.Modify(exportForm)
+	}
+
+	exportForm.Modify(response)
+
+	buttons := el.Div(
+		gr.CSS("btn-toolbar"),
+	)
+
+	// Close
+	el.Button(
+		evt.Click(e.closeButton).PreventDefault(),
+		gr.CSS("btn", "btn-secondary"),
+		gr.Text("Close"),
+	).Modify(buttons)
+
+	// Export
+	el.Button(
+		evt.Click(e.exportButton).PreventDefault(),
+		gr.CSS("btn", "btn-primary"),
+		gr.Text("Export"),
+	).Modify(buttons)
+
+	buttons.Modify(response)
+
+	return response
+}
+
+func (e ExportClasses) getClasses(scope string) {
+	classTypes := []string{scope}
+	if scope == "everything" {
+		classTypes = classBundleTypes
+	}
+
+	e.SetState(gr.State{"querying": true, "error": "", "success": ""})
+
+	go func() {
+		bundle, err := fetchClassBundle(classTypes)
+		if !e.IsMounted() {
+			return
+		}
+		if err != nil {
+			e.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		// Everything starts out selected
+		var selected []string
+		for _, entry := range bundle.Entries() {
+			selected = append(selected, entry.Key())
+		}
+
+		bundleJson, _ := json.Marshal(bundle)
+		e.SetState(gr.State{"querying": false, "bundle": bundleJson, "selected": selected})
+	}()
+}
+
+func (e ExportClasses) toggleClass(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	selected := stringSet(e.State().Interface("selected"))
+	selected[key] = event.Target().Get("checked").Bool()
+
+	var keys []string
+	for k, on := range selected {
+		if on {
+			keys = append(keys, k)
+		}
+	}
+	e.SetState(gr.State{"selected": keys})
+}
+
+func (e ExportClasses) selectAll(*gr.Event) {
+	var bundle ClassBundle
+	if bundleJson, ok := e.State().Interface("bundle").([]byte); ok {
+		json.Unmarshal(bundleJson, &bundle)
+	}
+
+	var keys []string
+	for _, entry := range bundle.Entries() {
+		keys = append(keys, entry.Key())
+	}
+	e.SetState(gr.State{"selected": keys})
+}
+
+func (e ExportClasses) selectNone(*gr.Event) {
+	e.SetState(gr.State{"selected": []string{}})
+}
+
+func (e ExportClasses) closeButton(*gr.Event) {
+	e.SetState(gr.State{"success": "", "error": ""})
+	hideAllModals()
+}
+
+func (e ExportClasses) exportButton(*gr.Event) {
+	state := e.State()
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		e.SetState(gr.State{"error": "No classes to export!"})
+		return
+	}
+
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	// Only keep the selected classes
+	selected := stringSet(state.Interface("selected"))
+	export := ClassBundle{Classes: make(map[string]map[string]map[string]interface{})}
+	for _, entry := range bundle.Entries() {
+		if !selected[entry.Key()] {
+			continue
+		}
+		if export.Classes[entry.Type] == nil {
+			export.Classes[entry.Type] = make(map[string]map[string]interface{})
+		}
+		export.Classes[entry.Type][entry.Name] = bundle.Classes[entry.Type][entry.Name]
+	}
+
+	if len(export.Classes) == 0 {
+		e.SetState(gr.State{"error": "Select at least one class to export!", "success": ""})
+		return
+	}
+
+	format := state.String("format")
+	data, err := export.Encode(format)
+	if err != nil {
+		e.SetState(gr.State{"error": err.Error(), "success": ""})
+		return
+	}
+
+	contentType := "application/json"
+	if format == "yaml" {
+		contentType = "application/x-yaml"
+	}
+
+	helpers.DownloadFile("awsm-classes-"+state.String("scope")+"."+format, contentType, data)
+	e.SetState(gr.State{"error": "", "success": "Classes were exported"})
+}
+
+func (e ExportClasses) storeSelect(key string, val interface{}) {
+	value, ok := val.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	e.SetState(gr.State{key: value["value"]})
+
+	if key == "scope" {
+		if scope, ok := value["value"].(string); ok {
+			e.getClasses(scope)
+		}
+	}
+}
+
+// stringSet turns a string slice pulled out of component state into a lookup map
+func stringSet(s interface{}) map[string]bool {
+	set := make(map[string]bool)
+
+	switch values := s.(type) {
+	case []string:
+		for _, v := range values {
+			set[v] = true
+		}
+	case []interface{}:
+		for _, v := range values {
+			if str, ok := v.(string); ok {
+				set[str] = true
+			}
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,439 @@
+package components
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+var (
+	// exposureReportPorts are always shown in the matrix, along with whatever port is being queried
+	exposureReportPorts = []int{22, 80, 443, 3389, 3306, 5432}
+
+	exposureSources   = []string{"internet", "anywhere"}
+	exposureProtocols = []string{"tcp", "udp", "icmp"}
+)
+
+type ExposureReport struct {
+	*gr.This
+}
+
+// exposureRow is an instance class, or an instance launched without one, and the sources that can reach it on each
+// port
+type exposureRow struct {
+	InstanceClass  string
+	SecurityGroups []string
+	Instances      []string
+	Sources        map[int][]string
+}
+
+// Name is the instance class, or the instance for rows without a class
+func (r exposureRow) Name() string {
+	if r.InstanceClass == "" && len(r.Instances) > 0 {
+		return r.Instances[0]
+	}
+	return r.InstanceClass
+}
+
+// Implements the StateInitializer interface
+func (e ExposureReport) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "bundle": nil, "instances": nil, "securityGroups": nil, "port": 22, "protocol": "tcp", "source": "internet"}
+}
+
+// Implements the ComponentWillMount interface
+func (e ExposureReport) ComponentWillMount() {
+	e.SetState(gr.State{"querying": true})
+
+	go func() {
+		bundle, err := fetchClassBundle([]string{"securitygroups", "instances"})
+		if !e.IsMounted() {
+			return
+		}
+		if err != nil {
+			e.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		assets := make(map[string][]byte)
+		for _, apiType := range []string{"instances", "securitygroups"} {
+			endpoint := "//localhost:8081/api/assets/" + apiType
+			resp, err := helpers.GetAPI(endpoint)
+			if !e.IsMounted() {
+				return
+			}
+			if err != nil {
+				e.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+			assets[apiType] = resp
+		}
+
+		bundleJson, _ := json.Marshal(bundle)
+		e.SetState(gr.State{"querying": false, "bundle": bundleJson, "instances": assets["instances"], "securityGroups": assets["securitygroups"]})
+	}()
+}
+
+func (e ExposureReport) Render() gr.Component {
+
+	state := e.State()
+
+	// Report placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return elem
+	}
+
+	port := state.Int("port")
+	protocol := state.String("protocol")
+	source := state.String("source")
+	ports := exposurePorts(protocol, port)
+	rows := e.buildRows(bundleJson, protocol, ports)
+
+	// Query
+	queryForm := el.Form(evt.KeyDown(forms.DisableEnter))
+	el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-2"),
+			forms.SelectOne("Protocol", "protocol", exposureProtocols, protocol, e.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			forms.NumberField(exposurePortName(protocol), "port", port, e.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-4"),
+			forms.CreateableSelectMeta("Reachable From", "source", exposureSources, map[string]string{"internet": "0.0.0.0/0 or ::/0", "anywhere": "any source"}, source, e.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-4"),
+			el.Label(gr.Text(" ")),
+			el.Button(
+				evt.Click(e.exportButton).PreventDefault(),
+				gr.CSS("btn", "btn-default", "btn-block"),
+				gr.Text("Export CSV"),
+			),
+		),
+	).Modify(queryForm)
+	queryForm.Modify(response)
+
+	// Answer
+	var reachable []string
+	liveCount := 0
+	for _, row := range rows {
+		if exposureMatches(row.Sources[port], source) {
+			reachable = append(reachable, row.Name())
+			liveCount += len(row.Instances)
+		}
+	}
+
+	answer := el.Div(gr.CSS("alert", "alert-success"))
+	if len(reachable) > 0 {
+		answer = el.Div(gr.CSS("alert", "alert-danger"))
+	}
+	gr.Text(fmt.Sprintf("%d instance classes and unclassed instances (%d live instances) are reachable on %s %s %d from %s", len(reachable), liveCount, protocol, strings.ToLower(exposurePortName(protocol)), port, source)).Modify(answer)
+	if len(reachable) > 0 {
+		gr.Text(": " + strings.Join(reachable, ", ")).Modify(answer)
+	}
+	answer.Modify(response)
+
+	// Port / Source matrix
+	header := exposureHeader(protocol, ports)
+
+	tBody := el.TableBody()
+	for _, row := range rows {
+		instanceClass := row.InstanceClass
+		if instanceClass == "" {
+			instanceClass = "(none)"
+		}
+		tr := el.TableRow(
+			el.TableData(gr.Text(instanceClass)),
+			el.TableData(gr.Text(strings.Join(row.SecurityGroups, ", "))),
+			el.TableData(gr.Text(strings.Join(row.Instances, ", "))),
+		)
+		for _, p := range ports {
+			td := el.TableData(gr.Text(strings.Join(row.Sources[p], ", ")))
+			if exposureMatches(row.Sources[p], "internet") {
+				gr.CSS("danger").Modify(td)
+			}
+			td.Modify(tr)
+		}
+		tr.Modify(tBody)
+	}
+
+	el.Table(
+		gr.CSS("table", "table-striped", "table-condensed"),
+		gr.Style("width", "100%"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader(header)...)),
+		tBody,
+	).Modify(response)
+
+	return elem
+}
+
+// buildRows works out which sources can reach each instance class, and each instance launched without a class, on each
+// port. Live instances are checked against the security groups they are running with, classes without live instances
+// against the security groups they launch with
+func (e ExposureReport) buildRows(bundleJson []byte, protocol string, ports []int) []exposureRow {
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	// Security group grants by group id and name, live groups first then classes
+	grantsByGroup := make(map[string][]interface{})
+	for name, class := range bundle.Classes["securitygroups"] {
+		grantsByGroup[name], _ = class["securityGroupGrants"].([]interface{})
+	}
+	if securityGroups, ok := e.State().Interface("securityGroups").([]byte); ok {
+		jsonParsed, _ := gabs.ParseJSON(securityGroups)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			grants, _ := asset.S("securityGroupGrants").Data().([]interface{})
+			for _, key := range []string{assetField(asset, "groupID", "GroupID"), assetField(asset, "name", "Name")} {
+				if key != "" {
+					grantsByGroup[key] = grants
+				}
+			}
+		}
+	}
+
+	// Live instances by class, instances without a class get a row each
+	classRows := make(map[string]*exposureRow)
+	var unclassed []*exposureRow
+	if instances, ok := e.State().Interface("instances").([]byte); ok {
+		jsonParsed, _ := gabs.ParseJSON(instances)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			class := assetField(asset, "class", "Class")
+			name := assetField(asset, "name", "Name")
+			if name == "" {
+				name = assetField(asset, "instanceID", "InstanceID")
+			}
+			if publicIP := assetField(asset, "publicIP", "PublicIP"); publicIP != "" {
+				name = name + " (" + publicIP + ")"
+			}
+
+			row, ok := classRows[class]
+			if !ok || class == "" {
+				row = &exposureRow{InstanceClass: class}
+				if class == "" {
+					unclassed = append(unclassed, row)
+				} else {
+					classRows[class] = row
+				}
+			}
+			row.Instances = append(row.Instances, name)
+			for _, sg := range assetList(asset, "securityGroups", "SecurityGroups", "securityGroupIDs") {
+				row.SecurityGroups = appendUniqueString(row.SecurityGroups, sg)
+			}
+		}
+	}
+
+	for name, class := range bundle.Classes["instances"] {
+		if _, ok := classRows[name]; !ok {
+			classRows[name] = &exposureRow{InstanceClass: name, SecurityGroups: helpers.ReferencedNames(class, "securityGroups")}
+		}
+	}
+
+	var names []string
+	for name := range classRows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var rows []exposureRow
+	for _, name := range names {
+		rows = append(rows, *classRows[name])
+	}
+	for _, row := range unclassed {
+		rows = append(rows, *row)
+	}
+
+	for i := range rows {
+		rows[i].Sources = make(map[int][]string)
+		for _, sg := range rows[i].SecurityGroups {
+			for _, port := range ports {
+				for _, src := range grantSources(grantsByGroup[sg], protocol, port) {
+					rows[i].Sources[port] = appendUniqueString(rows[i].Sources[port], src)
+				}
+			}
+		}
+	}
+
+	return rows
+}
+
+// grantSources returns every source allowed in by a list of grants on a port, or an icmp type
+func grantSources(grants []interface{}, protocol string, port int) []string {
+	var sources []string
+
+	for _, g := range grants {
+		grant, ok := g.(map[string]interface{})
+		if !ok || grant["type"] != "ingress" {
+			continue
+		}
+		if grantProtocol := forms.GrantProtocol(grant); grantProtocol != protocol && grantProtocol != "-1" {
+			continue
+		}
+
+		fromPort, toPort := forms.GrantPortRange(grant)
+		if port < fromPort || port > toPort {
+			continue
+		}
+
+		sources = append(sources, helpers.ReferencedNames(grant, "cidrIPs")...)
+		for _, sg := range helpers.ReferencedNames(grant, "sourceSecurityGroupNames") {
+			sources = append(sources, "sg:"+sg)
+		}
+	}
+
+	return sources
+}
+
+// exposureMatches reports if any of the sources can reach from the requested source. That can be "internet",
+// "anywhere", a security group as "sg:name", an ip, or a cidr that any source cidr overlaps
+func exposureMatches(sources []string, source string) bool {
+	if len(sources) == 0 {
+		return false
+	}
+
+	source = strings.TrimSpace(source)
+	switch {
+	case source == "anywhere" || source == "":
+		return true
+	case source == "internet":
+		for _, src := range sources {
+			if src == "0.0.0.0/0" || src == "::/0" {
+				return true
+			}
+		}
+		return false
+	case strings.HasPrefix(source, "sg:"):
+		for _, src := range sources {
+			if src == source {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sourceNet *net.IPNet
+	if ip := net.ParseIP(source); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		sourceNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+	} else if _, ipNet, err := net.ParseCIDR(source); err == nil {
+		sourceNet = ipNet
+	} else {
+		return false
+	}
+
+	for _, src := range sources {
+		if _, ipNet, err := net.ParseCIDR(src); err == nil && (ipNet.Contains(sourceNet.IP) || sourceNet.Contains(ipNet.IP)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exposurePorts are the matrix columns, icmp only shows the queried type
+func exposurePorts(protocol string, port int) []int {
+	if protocol == "icmp" {
+		return []int{port}
+	}
+
+	ports := append([]int{}, exposureReportPorts...)
+	for _, p := range ports {
+		if p == port {
+			return ports
+		}
+	}
+	if port > 0 {
+		ports = append([]int{port}, ports...)
+	}
+	return ports
+}
+
+// exposurePortName is what a port is called for the protocol
+func exposurePortName(protocol string) string {
+	if protocol == "icmp" {
+		return "Type"
+	}
+	return "Port"
+}
+
+func exposureHeader(protocol string, ports []int) []string {
+	header := []string{"Instance Class", "Security Groups", "Instances"}
+	for _, p := range ports {
+		header = append(header, strings.ToUpper(protocol)+" "+exposurePortName(protocol)+" "+strconv.Itoa(p))
+	}
+	return header
+}
+
+func appendUniqueString(list []string, s string) []string {
+	for _, l := range list {
+		if l == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func (e ExposureReport) exportButton(*gr.Event) {
+	state := e.State()
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return
+	}
+
+	protocol := state.String("protocol")
+	ports := exposurePorts(protocol, state.Int("port"))
+	rows := e.buildRows(bundleJson, protocol, ports)
+
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Write(exposureHeader(protocol, ports))
+	for _, row := range rows {
+		record := []string{row.InstanceClass, strings.Join(row.SecurityGroups, " "), strings.Join(row.Instances, " ")}
+		for _, p := range ports {
+			record = append(record, strings.Join(row.Sources[p], " "))
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	helpers.DownloadFile("awsm-exposure.csv", "text/csv", buf.Bytes())
+}
+
+func (e ExposureReport) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	e.SetState(gr.State{key: event.TargetValue().Int()})
+}
+
+func (e ExposureReport) storeSelect(key string, val interface{}) {
+	if value, ok := val.(map[string]interface{}); ok {
+		e.SetState(gr.State{key: value["value"]})
+	}
+}
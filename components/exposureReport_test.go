@@ -0,0 +1,107 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+)
+
+func exposureGrant(protocol string, fromPort, toPort float64, cidrs []interface{}, groups []interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "ingress", "ipProtocol": protocol, "fromPort": fromPort, "toPort": toPort, "cidrIPs": cidrs, "sourceSecurityGroupNames": groups}
+}
+
+func TestGrantSources(t *testing.T) {
+	grants := []interface{}{
+		exposureGrant("tcp", 22, 22, []interface{}{"10.0.0.0/16"}, nil),
+		exposureGrant("tcp", 80, 443, []interface{}{"0.0.0.0/0", "::/0"}, nil),
+		exposureGrant("tcp", 5432, 5432, nil, []interface{}{"web", "worker"}),
+		exposureGrant("udp", 53, 53, []interface{}{"10.0.0.2/32"}, nil),
+		exposureGrant("icmp", 8, -1, []interface{}{"192.168.0.0/16"}, nil),
+		exposureGrant("icmp", -1, -1, []interface{}{"10.1.0.0/16"}, nil),
+		exposureGrant("-1", -1, -1, nil, []interface{}{"bastion"}),
+		map[string]interface{}{"type": "egress", "ipProtocol": "tcp", "fromPort": float64(22), "toPort": float64(22), "cidrIPs": []interface{}{"0.0.0.0/0"}},
+		"not a grant",
+	}
+
+	tests := []struct {
+		protocol string
+		port     int
+		want     []string
+	}{
+		{protocol: "tcp", port: 22, want: []string{"10.0.0.0/16", "sg:bastion"}},
+		{protocol: "tcp", port: 80, want: []string{"0.0.0.0/0", "::/0", "sg:bastion"}},
+		{protocol: "tcp", port: 200, want: []string{"0.0.0.0/0", "::/0", "sg:bastion"}},
+		{protocol: "tcp", port: 5432, want: []string{"sg:web", "sg:worker", "sg:bastion"}},
+		{protocol: "udp", port: 53, want: []string{"10.0.0.2/32", "sg:bastion"}},
+		{protocol: "udp", port: 22, want: []string{"sg:bastion"}},
+		{protocol: "icmp", port: 8, want: []string{"192.168.0.0/16", "10.1.0.0/16", "sg:bastion"}},
+		{protocol: "icmp", port: 0, want: []string{"10.1.0.0/16", "sg:bastion"}},
+	}
+
+	for _, test := range tests {
+		if got := grantSources(grants, test.protocol, test.port); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("grantSources(%s %d) = %v, want %v", test.protocol, test.port, got, test.want)
+		}
+	}
+
+	if got := grantSources(grants[:1], "tcp", 443); got != nil {
+		t.Errorf("grantSources() on a closed port = %v, want nothing", got)
+	}
+}
+
+func TestExposureMatches(t *testing.T) {
+	tests := []struct {
+		sources []string
+		source  string
+		want    bool
+	}{
+		{sources: nil, source: "anywhere", want: false},
+		{sources: []string{"sg:web"}, source: "anywhere", want: true},
+		{sources: []string{"10.0.0.0/16"}, source: "", want: true},
+		{sources: []string{"0.0.0.0/0"}, source: "internet", want: true},
+		{sources: []string{"::/0"}, source: "internet", want: true},
+		{sources: []string{"10.0.0.0/8", "sg:web"}, source: "internet", want: false},
+		{sources: []string{"sg:web", "sg:worker"}, source: "sg:worker", want: true},
+		{sources: []string{"sg:web"}, source: "sg:bastion", want: false},
+		{sources: []string{"0.0.0.0/0"}, source: "sg:web", want: false},
+		{sources: []string{"10.0.0.0/16"}, source: "10.0.3.4", want: true},
+		{sources: []string{"10.0.0.0/16"}, source: "10.1.3.4", want: false},
+		{sources: []string{"10.0.0.5/32"}, source: "10.0.0.5", want: true},
+		{sources: []string{"sg:web", "0.0.0.0/0"}, source: "203.0.113.9", want: true},
+		{sources: []string{"10.0.0.0/16"}, source: "10.0.1.0/24", want: true},
+		{sources: []string{"10.0.1.0/24"}, source: "10.0.0.0/16", want: true},
+		{sources: []string{"10.0.1.0/24"}, source: "10.0.2.0/24", want: false},
+		{sources: []string{"::/0"}, source: "2001:db8::1", want: true},
+		{sources: []string{"10.0.0.0/16"}, source: "not an ip", want: false},
+	}
+
+	for _, test := range tests {
+		if got := exposureMatches(test.sources, test.source); got != test.want {
+			t.Errorf("exposureMatches(%v, %q) = %t, want %t", test.sources, test.source, got, test.want)
+		}
+	}
+}
+
+func TestExposurePorts(t *testing.T) {
+	tests := []struct {
+		protocol string
+		port     int
+		want     []int
+	}{
+		{protocol: "tcp", port: 22, want: []int{22, 80, 443, 3389, 3306, 5432}},
+		{protocol: "tcp", port: 8080, want: []int{8080, 22, 80, 443, 3389, 3306, 5432}},
+		{protocol: "udp", port: 0, want: []int{22, 80, 443, 3389, 3306, 5432}},
+		{protocol: "icmp", port: 8, want: []int{8}},
+		{protocol: "icmp", port: 0, want: []int{0}},
+	}
+
+	for _, test := range tests {
+		if got := exposurePorts(test.protocol, test.port); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("exposurePorts(%s %d) = %v, want %v", test.protocol, test.port, got, test.want)
+		}
+	}
+
+	exposurePorts("tcp", 8080)
+	if !reflect.DeepEqual(exposureReportPorts, []int{22, 80, 443, 3389, 3306, 5432}) {
+		t.Errorf("exposurePorts() changed exposureReportPorts to %v", exposureReportPorts)
+	}
+}
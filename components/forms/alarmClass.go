@@ -161,14 +161,16 @@ func (a AlarmClassForm) BuildClassForm(className string, optionsResp interface{}
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(a.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(a.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(a.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -176,7 +178,17 @@ func (a AlarmClassForm) BuildClassForm(className string, optionsResp interface{}
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": a.deleteClass,
+			"onCancel":  a.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -219,7 +231,15 @@ func (a AlarmClassForm) saveButton(*gr.Event) {
 }
 
 func (a AlarmClassForm) deleteButton(*gr.Event) {
-	a.SetState(gr.State{"querying": true})
+	a.SetState(gr.State{"confirmDelete": true})
+}
+
+func (a AlarmClassForm) cancelDelete() {
+	a.SetState(gr.State{"confirmDelete": false})
+}
+
+func (a AlarmClassForm) deleteClass() {
+	a.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + a.Props().String("apiType") + "/name/" + a.Props().String("className")
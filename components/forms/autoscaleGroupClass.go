@@ -154,14 +154,16 @@ func (a AutoscaleGroupClassForm) BuildClassForm(className string, optionsResp in
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(a.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(a.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(a.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -169,7 +171,17 @@ func (a AutoscaleGroupClassForm) BuildClassForm(className string, optionsResp in
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": a.deleteClass,
+			"onCancel":  a.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -212,7 +224,15 @@ func (a AutoscaleGroupClassForm) saveButton(*gr.Event) {
 }
 
 func (a AutoscaleGroupClassForm) deleteButton(*gr.Event) {
-	a.SetState(gr.State{"querying": true})
+	a.SetState(gr.State{"confirmDelete": true})
+}
+
+func (a AutoscaleGroupClassForm) cancelDelete() {
+	a.SetState(gr.State{"confirmDelete": false})
+}
+
+func (a AutoscaleGroupClassForm) deleteClass() {
+	a.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + a.Props().String("apiType") + "/name/" + a.Props().String("className")
@@ -0,0 +1,245 @@
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// CidrPlanner shows how a vpc's address space is carved up by subnet classes and suggests free subnets
+type CidrPlanner struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (c CidrPlanner) GetInitialState() gr.State {
+	return gr.State{"querying": true, "error": "", "vpcCidrs": nil, "subnetCidrs": nil, "zones": []string{}, "vpc": "", "prefix": 24}
+}
+
+// Implements the ComponentWillMount interface
+func (c CidrPlanner) ComponentWillMount() {
+	go func() {
+		vpcCidrs, err := classCidrs("vpcs")
+		if !c.IsMounted() {
+			return
+		}
+		if err != nil {
+			c.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		subnetCidrs, err := classCidrs("subnets")
+		if !c.IsMounted() {
+			return
+		}
+		if err != nil {
+			c.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		var zones []string
+		endpoint := "//localhost:8081/api/classes/subnets/options"
+		if resp, err := helpers.GetAPI(endpoint); err == nil {
+			var classOptions map[string][]string
+			jsonParsed, _ := gabs.ParseJSON(resp)
+			json.Unmarshal(jsonParsed.S("classOptions").Bytes(), &classOptions)
+			zones = classOptions["zones"]
+		}
+		if !c.IsMounted() {
+			return
+		}
+
+		vpcJson, _ := json.Marshal(vpcCidrs)
+		subnetJson, _ := json.Marshal(subnetCidrs)
+		c.SetState(gr.State{"querying": false, "vpcCidrs": vpcJson, "subnetCidrs": subnetJson, "zones": zones})
+	}()
+}
+
+func (c CidrPlanner) Render() gr.Component {
+
+	state := c.State()
+	props := c.Props()
+
+	planner := el.Div(gr.CSS("cidr-planner", "well"))
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(planner)
+		return planner
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(planner)
+
+	var vpcCidrs, subnetCidrs map[string]string
+	if vpcJson, ok := state.Interface("vpcCidrs").([]byte); ok {
+		json.Unmarshal(vpcJson, &vpcCidrs)
+	}
+	if subnetJson, ok := state.Interface("subnetCidrs").([]byte); ok {
+		json.Unmarshal(subnetJson, &subnetCidrs)
+	}
+
+	// Pick the vpc address space
+	parentCidr := props.String("vpcCidr")
+	if parentCidr == "" {
+		var vpcs []string
+		for name := range vpcCidrs {
+			vpcs = append(vpcs, name)
+		}
+		sort.Strings(vpcs)
+
+		SelectOneMeta("VPC Class", "vpc", vpcs, vpcCidrs, state.Interface("vpc"), c.storeSelect).Modify(planner)
+		parentCidr = vpcCidrs[state.String("vpc")]
+	}
+
+	if parentCidr == "" {
+		return planner
+	}
+
+	parent, err := helpers.ParseCidrBlock(parentCidr)
+	if err != nil {
+		helpers.ErrorElem(err.Error()).Modify(planner)
+		return planner
+	}
+
+	// Subnet classes carved out of it, not counting the one being edited
+	var used []helpers.CidrBlock
+	usedNames := make(map[string]string)
+	for name, cidr := range subnetCidrs {
+		if name == props.String("className") {
+			continue
+		}
+		block, err := helpers.ParseCidrBlock(cidr)
+		if err != nil || !parent.Overlaps(block) {
+			continue
+		}
+		used = append(used, block)
+		usedNames[block.Cidr] = usedNames[block.Cidr] + " " + name
+	}
+
+	free := helpers.FreeCidrBlocks(parent, used)
+
+	// Address space bar
+	bar := el.Div(gr.CSS("cidr-planner-bar"))
+	for _, block := range used {
+		cidrPlannerSegment(parent, block, "used", block.Cidr+usedNames[block.Cidr]).Modify(bar)
+	}
+	for _, block := range free {
+		cidrPlannerSegment(parent, block, "free", block.Cidr+" free").Modify(bar)
+	}
+
+	el.Div(
+		el.Label(gr.Text(parent.Cidr+" - "+fmt.Sprint(parent.Size())+" addresses")),
+		bar,
+	).Modify(planner)
+
+	// Free ranges
+	freeList := el.UnorderedList(gr.CSS("list-inline"))
+	for _, block := range free {
+		el.ListItem(el.Code(gr.Text(block.Cidr))).Modify(freeList)
+	}
+	el.Div(
+		el.Label(gr.Text("Free Ranges")),
+		freeList,
+	).Modify(planner)
+
+	// Suggestions, one per availability zone
+	if props.Interface("onSelect") == nil {
+		return planner
+	}
+
+	NumberField("Subnet Size (prefix length)", "prefix", state.Int("prefix"), c.storeValue).Modify(planner)
+
+	zones := stringList(state.Interface("zones"))
+	if len(zones) == 0 {
+		zones = []string{"next available"}
+	}
+
+	suggestions, err := helpers.NextCidrBlocks(parent, used, state.Int("prefix"), len(zones))
+	if err != nil {
+		el.Div(
+			gr.CSS("invalid-message"),
+			el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+			gr.Text(" - "+err.Error()),
+		).Modify(planner)
+	}
+
+	tBody := el.TableBody()
+	for i, block := range suggestions {
+		el.TableRow(
+			el.TableData(gr.Text(zones[i])),
+			el.TableData(el.Code(gr.Text(block.Cidr))),
+			el.TableData(
+				el.Button(
+					evt.Click(c.selectCidr(block.Cidr)).PreventDefault(),
+					gr.CSS("btn", "btn-primary", "btn-xs", "pull-right"),
+					gr.Text("Use"),
+				),
+			),
+		).Modify(tBody)
+	}
+
+	el.Table(
+		gr.CSS("table", "table-condensed"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Zone", "Suggested CIDR", ""})...)),
+		tBody,
+	).Modify(planner)
+
+	return planner
+}
+
+func cidrPlannerSegment(parent, block helpers.CidrBlock, css, title string) *gr.Element {
+	left := float64(uint64(block.Start)-uint64(parent.Start)) / float64(parent.Size()) * 100
+	width := float64(block.Size()) / float64(parent.Size()) * 100
+
+	return el.Div(
+		gr.CSS("cidr-planner-segment", css),
+		gr.Style("left", fmt.Sprintf("%.4f%%", left)),
+		gr.Style("width", fmt.Sprintf("%.4f%%", width)),
+		gr.Prop("title", title),
+	)
+}
+
+// classCidrs returns the cidr of every class of the given type
+func classCidrs(classType string) (map[string]string, error) {
+	cidrs := make(map[string]string)
+
+	endpoint := "//localhost:8081/api/classes/" + classType
+	resp, err := helpers.GetAPI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error while querying endpoint: %s", endpoint)
+	}
+
+	jsonParsed, _ := gabs.ParseJSON(resp)
+	classes, _ := jsonParsed.S("classes").ChildrenMap()
+	for name, class := range classes {
+		if cidr, ok := class.S("cidr").Data().(string); ok && cidr != "" {
+			cidrs[name] = cidr
+		}
+	}
+
+	return cidrs, nil
+}
+
+func (c CidrPlanner) selectCidr(cidr string) func(*gr.Event) {
+	return func(*gr.Event) {
+		c.Props().Call("onSelect", cidr)
+	}
+}
+
+func (c CidrPlanner) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	c.SetState(gr.State{key: event.TargetValue().Int()})
+}
+
+func (c CidrPlanner) storeSelect(key string, val interface{}) {
+	if value, ok := val.(map[string]interface{}); ok {
+		c.SetState(gr.State{key: value["value"]})
+	} else {
+		c.SetState(gr.State{key: ""})
+	}
+}
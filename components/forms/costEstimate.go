@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// costRegion returns the region picked for a cost estimate, defaulting to the region the price tables are in
+func costRegion(region string) string {
+	if region == "" {
+		return helpers.DefaultCostRegion
+	}
+	return region
+}
+
+// CostEstimate shows an estimated monthly cost with a region picker, or why there is no estimate
+func CostEstimate(monthly float64, ok bool, note, region string, storeSelect func(string, interface{})) *gr.Element {
+	estimate := el.Strong(gr.Text(helpers.FormatCost(monthly)))
+	if !ok {
+		estimate = el.Strong(gr.Text("No price available"))
+	}
+
+	return el.Div(
+		gr.CSS("row", "cost-estimate"),
+		el.Div(gr.CSS("col-sm-6"),
+			SelectOne("Pricing Region", "costRegion", helpers.CostRegions, costRegion(region), storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-6"),
+			el.Label(gr.Text("Estimated Monthly Cost")),
+			el.Div(
+				gr.CSS("well", "well-sm"),
+				estimate,
+				el.Break(),
+				el.Small(gr.Text(note)),
+			),
+		),
+	)
+}
@@ -0,0 +1,183 @@
+package forms
+
+import (
+	"encoding/json"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// DeleteClassConfirm lists everything that depends on a class and asks for confirmation before deleting it
+type DeleteClassConfirm struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (d DeleteClassConfirm) GetInitialState() gr.State {
+	return gr.State{"querying": true, "error": "", "referrers": nil, "assets": nil, "override": false}
+}
+
+// Implements the ComponentWillMount interface
+func (d DeleteClassConfirm) ComponentWillMount() {
+	apiType := d.Props().String("apiType")
+	className := d.Props().String("className")
+
+	go func() {
+		referrers, err := helpers.FindClassReferrers(apiType, className)
+		if !d.IsMounted() {
+			return
+		}
+		if err != nil {
+			d.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		assets, err := helpers.FindClassAssets(apiType, className)
+		if !d.IsMounted() {
+			return
+		}
+		if err != nil {
+			d.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		referrersJson, _ := json.Marshal(referrers)
+		assetsJson, _ := json.Marshal(assets)
+		d.SetState(gr.State{"querying": false, "referrers": referrersJson, "assets": assetsJson})
+	}()
+}
+
+func (d DeleteClassConfirm) Render() gr.Component {
+
+	state := d.State()
+	props := d.Props()
+
+	response := el.Div(
+		gr.CSS("panel", "panel-danger"),
+		el.Div(
+			gr.CSS("panel-heading"),
+			gr.Text("Delete "+props.String("className")+"?"),
+		),
+	)
+
+	body := el.Div(gr.CSS("panel-body"))
+
+	if state.Bool("querying") {
+		gr.Text("Checking for references...").Modify(body)
+		body.Modify(response)
+		return response
+	}
+
+	// The check failing shouldn't make it impossible to delete, but it does need an override
+	blocked := false
+	if errStr := state.String("error"); errStr != "" {
+		helpers.ErrorElem("Unable to check for references: " + errStr).Modify(body)
+		blocked = true
+	}
+
+	var referrers []helpers.ClassLink
+	if referrersJson, ok := state.Interface("referrers").([]byte); ok {
+		json.Unmarshal(referrersJson, &referrers)
+	}
+
+	var assets []helpers.ClassAsset
+	if assetsJson, ok := state.Interface("assets").([]byte); ok {
+		json.Unmarshal(assetsJson, &assets)
+	}
+
+	if len(referrers) > 0 {
+		blocked = true
+		list := el.UnorderedList()
+		for _, ref := range referrers {
+			el.ListItem(
+				el.Strong(gr.Text(ref.FromName)),
+				gr.Text(" ("+ref.FromType+" class, "+ref.Field+")"),
+			).Modify(list)
+		}
+		el.Div(
+			el.Paragraph(gr.Text("The following classes reference this class:")),
+			list,
+		).Modify(body)
+	}
+
+	if len(assets) > 0 {
+		blocked = true
+		list := el.UnorderedList()
+		for _, asset := range assets {
+			name := asset.Name
+			if asset.ID != "" && asset.ID != asset.Name {
+				name = name + " - " + asset.ID
+			}
+			el.ListItem(gr.Text(name)).Modify(list)
+		}
+		el.Div(
+			el.Paragraph(gr.Text("The following live assets were created from this class:")),
+			list,
+		).Modify(body)
+	}
+
+	if !blocked {
+		el.Paragraph(gr.Text("Nothing references this class.")).Modify(body)
+	} else {
+		var checked gr.Modifier
+		if state.Bool("override") {
+			checked = attr.Checked(true)
+		}
+
+		el.Div(
+			gr.CSS("checkbox"),
+			el.Label(
+				el.Input(
+					attr.Value(""), // to stop the warning about a uncontrolled components
+					attr.Type("checkbox"),
+					attr.Name("override"),
+					checked,
+					evt.Change(d.storeOverride).StopPropagation(),
+				),
+				gr.Text("Delete anyway, I understand this will leave broken references"),
+			),
+		).Modify(body)
+	}
+
+	buttons := el.Div(
+		gr.CSS("btn-toolbar"),
+	)
+
+	// Cancel
+	el.Button(
+		evt.Click(d.cancelButton).PreventDefault(),
+		gr.CSS("btn", "btn-secondary"),
+		gr.Text("Cancel"),
+	).Modify(buttons)
+
+	// Delete
+	deleteButton := el.Button(
+		evt.Click(d.deleteButton).PreventDefault(),
+		gr.CSS("btn", "btn-danger", "pull-right"),
+		gr.Text("Delete"),
+	)
+	if blocked && !state.Bool("override") {
+		attr.Disabled(true).Modify(deleteButton)
+	}
+	deleteButton.Modify(buttons)
+
+	buttons.Modify(body)
+	body.Modify(response)
+
+	return response
+}
+
+func (d DeleteClassConfirm) storeOverride(event *gr.Event) {
+	d.SetState(gr.State{"override": event.Target().Get("checked").Bool()})
+}
+
+func (d DeleteClassConfirm) cancelButton(*gr.Event) {
+	d.Props().Call("onCancel")
+}
+
+func (d DeleteClassConfirm) deleteButton(*gr.Event) {
+	d.Props().Call("onConfirm")
+}
@@ -37,6 +37,26 @@ func TextField(name, key string, v interface{}, storeFunc func(*gr.Event)) *gr.E
 	)
 }
 
+// TextFieldOnBlur is a TextField that also calls blurFunc when it loses focus, for values that are cleaned up once typed
+func TextFieldOnBlur(name, key string, v interface{}, storeFunc, blurFunc func(*gr.Event)) *gr.Element {
+	value, _ := v.(string)
+	return el.Div(
+		gr.CSS("form-group"),
+		el.Label(
+			gr.Text(name),
+		),
+		el.Input(
+			attr.Type("text"),
+			attr.ClassName("form-control"),
+			attr.Name(key),
+			attr.Placeholder(name),
+			attr.Value(value),
+			evt.Change(storeFunc),
+			evt.Blur(blurFunc),
+		),
+	)
+}
+
 func NumberField(name, key string, value interface{}, storeFunc func(*gr.Event)) *gr.Element {
 
 	return el.Div(
@@ -72,6 +92,22 @@ func TextArea(name, key string, value string, storeFunc func(*gr.Event)) *gr.Ele
 	)
 }
 
+func FileField(name, key, accept string, storeFunc func(*gr.Event)) *gr.Element {
+	return el.Div(
+		gr.CSS("form-group"),
+		el.Label(
+			gr.Text(name),
+		),
+		el.Input(
+			attr.Type("file"),
+			attr.ClassName("form-control"),
+			attr.Name(key),
+			attr.Accept(accept),
+			evt.Change(storeFunc),
+		),
+	)
+}
+
 func Checkbox(name, key string, value bool, storeFunc func(*gr.Event)) *gr.Element {
 
 	label := "disabled"
@@ -102,6 +138,36 @@ func Checkbox(name, key string, value bool, storeFunc func(*gr.Event)) *gr.Eleme
 	)
 }
 
+// DisabledCheckbox shows a checkbox that can't be changed, with the reason why
+func DisabledCheckbox(name string, value bool, reason string) *gr.Element {
+
+	label := "disabled"
+	var checked gr.Modifier
+	if value {
+		label = "enabled"
+		checked = attr.Checked(true)
+	}
+
+	return el.Div(
+		gr.CSS("form-group"),
+		el.Label(
+			gr.Text(name),
+		),
+		el.Div(
+			gr.CSS("checkbox", "disabled"),
+			el.Label(
+				el.Input(
+					attr.Type("checkbox"),
+					attr.Disabled(true),
+					attr.ReadOnly(true),
+					checked,
+				),
+				gr.Text(label+" - "+reason),
+			),
+		),
+	)
+}
+
 func Toggle(falseName, trueName, key string, value interface{}, storeFunc func(*gr.Event)) *gr.Element {
 
 	valBool, ok := value.(bool)
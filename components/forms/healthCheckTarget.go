@@ -0,0 +1,84 @@
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var healthCheckProtocols = []string{"HTTP", "HTTPS", "TCP", "SSL"}
+
+// HealthCheckTarget is a load balancer health check target, serialized by AWS as "HTTP:80/health" or "TCP:22"
+type HealthCheckTarget struct {
+	Protocol string
+	Port     int
+	Path     string
+}
+
+// HasPath reports if the target protocol takes a path
+func (h HealthCheckTarget) HasPath() bool {
+	return h.Protocol == "HTTP" || h.Protocol == "HTTPS"
+}
+
+// String serializes the target in the AWS format
+func (h HealthCheckTarget) String() string {
+	target := h.Protocol + ":" + strconv.Itoa(h.Port)
+	if h.HasPath() {
+		path := h.Path
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		target += path
+	}
+	return target
+}
+
+// Validate returns a description of what's wrong with the target, or an empty string if it is valid
+func (h HealthCheckTarget) Validate() string {
+	switch {
+	case h.Protocol == "":
+		return "Health check target needs a protocol"
+	case h.Port < 1 || h.Port > 65535:
+		return "Health check port must be between 1 and 65535"
+	case h.HasPath() && strings.ContainsAny(h.Path, " ?#"):
+		return "Health check path can't contain spaces, query strings or fragments"
+	}
+	return ""
+}
+
+// ParseHealthCheckTarget parses an existing target, defaulting anything missing to HTTP:80/
+func ParseHealthCheckTarget(target string) HealthCheckTarget {
+	h := HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "/"}
+
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return h
+	}
+
+	protocol, rest := target, ""
+	if i := strings.Index(target, ":"); i >= 0 {
+		protocol, rest = target[:i], target[i+1:]
+	}
+	h.Protocol = strings.ToUpper(protocol)
+
+	port := rest
+	h.Path = ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		port, h.Path = rest[:i], rest[i:]
+	}
+	h.Port, _ = strconv.Atoi(port)
+
+	if h.HasPath() && h.Path == "" {
+		h.Path = "/"
+	}
+
+	return h
+}
+
+// ValidateHealthCheckTiming makes sure the timeout is shorter than the interval
+func ValidateHealthCheckTiming(timeout, interval int) string {
+	if timeout > 0 && interval > 0 && timeout >= interval {
+		return fmt.Sprintf("Timeout (%ds) must be less than the Interval (%ds)", timeout, interval)
+	}
+	return ""
+}
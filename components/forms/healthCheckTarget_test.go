@@ -0,0 +1,87 @@
+package forms
+
+import "testing"
+
+func TestParseHealthCheckTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   HealthCheckTarget
+		str    string
+	}{
+		{target: "", want: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "/"}, str: "HTTP:80/"},
+		{target: "HTTP:8080/health", want: HealthCheckTarget{Protocol: "HTTP", Port: 8080, Path: "/health"}, str: "HTTP:8080/health"},
+		{target: "https:443", want: HealthCheckTarget{Protocol: "HTTPS", Port: 443, Path: "/"}, str: "HTTPS:443/"},
+		{target: " TCP:22 ", want: HealthCheckTarget{Protocol: "TCP", Port: 22}, str: "TCP:22"},
+		{target: "SSL:443/ignored", want: HealthCheckTarget{Protocol: "SSL", Port: 443, Path: "/ignored"}, str: "SSL:443"},
+		{target: "HTTP", want: HealthCheckTarget{Protocol: "HTTP", Path: "/"}, str: "HTTP:0/"},
+	}
+
+	for _, test := range tests {
+		got := ParseHealthCheckTarget(test.target)
+		if got != test.want {
+			t.Errorf("ParseHealthCheckTarget(%q) = %+v, want %+v", test.target, got, test.want)
+		}
+		if got.String() != test.str {
+			t.Errorf("ParseHealthCheckTarget(%q).String() = %q, want %q", test.target, got.String(), test.str)
+		}
+	}
+}
+
+func TestHealthCheckTargetString(t *testing.T) {
+	tests := []struct {
+		target HealthCheckTarget
+		want   string
+	}{
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "health"}, want: "HTTP:80/health"},
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: ""}, want: "HTTP:80/"},
+		{target: HealthCheckTarget{Protocol: "TCP", Port: 22, Path: "/health"}, want: "TCP:22"},
+	}
+
+	for _, test := range tests {
+		if got := test.target.String(); got != test.want {
+			t.Errorf("%+v.String() = %q, want %q", test.target, got, test.want)
+		}
+	}
+}
+
+func TestHealthCheckTargetValidate(t *testing.T) {
+	tests := []struct {
+		target HealthCheckTarget
+		valid  bool
+	}{
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "/health"}, valid: true},
+		{target: HealthCheckTarget{Protocol: "TCP", Port: 65535}, valid: true},
+		{target: HealthCheckTarget{Protocol: "TCP", Port: 22, Path: "has a space"}, valid: true},
+		{target: HealthCheckTarget{Port: 80}},
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 0, Path: "/"}},
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 65536, Path: "/"}},
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "/health check"}},
+		{target: HealthCheckTarget{Protocol: "HTTPS", Port: 443, Path: "/health?full=1"}},
+		{target: HealthCheckTarget{Protocol: "HTTP", Port: 80, Path: "/health#top"}},
+	}
+
+	for _, test := range tests {
+		if problem := test.target.Validate(); (problem == "") != test.valid {
+			t.Errorf("%+v.Validate() = %q, want valid %t", test.target, problem, test.valid)
+		}
+	}
+}
+
+func TestValidateHealthCheckTiming(t *testing.T) {
+	tests := []struct {
+		timeout, interval int
+		valid             bool
+	}{
+		{timeout: 5, interval: 30, valid: true},
+		{timeout: 0, interval: 30, valid: true},
+		{timeout: 5, interval: 0, valid: true},
+		{timeout: 30, interval: 30},
+		{timeout: 60, interval: 30},
+	}
+
+	for _, test := range tests {
+		if problem := ValidateHealthCheckTiming(test.timeout, test.interval); (problem == "") != test.valid {
+			t.Errorf("ValidateHealthCheckTiming(%d, %d) = %q, want valid %t", test.timeout, test.interval, problem, test.valid)
+		}
+	}
+}
@@ -173,14 +173,16 @@ func (i ImageClassForm) BuildClassForm(className string, optionsResp interface{}
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(i.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(i.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(i.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -188,7 +190,17 @@ func (i ImageClassForm) BuildClassForm(className string, optionsResp interface{}
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": i.deleteClass,
+			"onCancel":  i.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -231,7 +243,15 @@ func (i ImageClassForm) saveButton(*gr.Event) {
 }
 
 func (i ImageClassForm) deleteButton(*gr.Event) {
-	i.SetState(gr.State{"querying": true})
+	i.SetState(gr.State{"confirmDelete": true})
+}
+
+func (i ImageClassForm) cancelDelete() {
+	i.SetState(gr.State{"confirmDelete": false})
+}
+
+func (i ImageClassForm) deleteClass() {
+	i.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + i.Props().String("apiType") + "/name/" + i.Props().String("className")
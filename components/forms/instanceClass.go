@@ -6,19 +6,13 @@ import (
 
 	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
 	"github.com/bep/gr/el"
 	"github.com/bep/gr/evt"
 	"github.com/murdinc/awsmDashboard/helpers"
 )
 
 var (
-	instanceTypes = []string{
-		"t2.nano", "t2.micro", "t2.small", "t2.medium", "t2.large", "m4.large", "m4.xlarge", "m4.2xlarge", "m4.4xlarge", "m4.10xlarge", "m4.16xlarge", "m3.medium",
-		"m3.large", "m3.xlarge", "m3.2xlarge", "c4.large", "c4.xlarge", "c4.2xlarge", "c4.4xlarge", "c4.8xlarge", "c3.large", "c3.xlarge", "c3.2xlarge", "c3.4xlarge",
-		"c3.8xlarge", "r3.large", "r3.xlarge", "r3.2xlarge", "r3.4xlarge", "r3.8xlarge", "x1.16xlarge", "x1.32xlarge", "i2.xlarge", "i2.2xlarge", "i2.4xlarge",
-		"i2.8xlarge", "d2.xlarge", "d2.2xlarge", "d2.4xlarge", "d2.8xlarge", "p2.xlarge", "p2.8xlarge", "p2.16xlarge", "g2.2xlarge", "g2.8xlarge",
-	}
-
 	shutdownBehaviors = []string{"stop", "terminate"}
 )
 
@@ -45,8 +39,12 @@ func (i InstanceClassForm) ComponentWillMount() {
 		json.Unmarshal(classJson, &class)
 	}
 
+	// Types that are always EBS optimized, or never can be, show that setting, so load it that way too
+	instanceType, _ := class["instanceType"].(string)
+	ebsOptimized, _ := class["ebsOptimized"].(bool)
+
 	i.SetState(class)
-	i.SetState(gr.State{"queryingOpts": true, "queryingIams": true})
+	i.SetState(gr.State{"queryingOpts": true, "queryingIams": true, "ebsOptimized": helpers.NormalizeEbsOptimized(instanceType, ebsOptimized)})
 
 	// Get our options for the form
 	go func() {
@@ -159,7 +157,51 @@ func (i InstanceClassForm) BuildClassForm(className string, optionsResp interfac
 
 	classEditForm := el.Form(evt.KeyDown(DisableEnter))
 
-	SelectOne("Instance Type", "instanceType", instanceTypes, state.Interface("instanceType"), i.storeSelect).Modify(classEditForm)
+	// Instance type, picked from the catalog
+	catalog := helpers.InstanceTypeCatalog(classOptions["instanceTypes"])
+	instanceType, knownType := helpers.FindInstanceType(catalog, state.String("instanceType"))
+
+	typeSummary := "None selected"
+	if knownType && instanceType.Known() {
+		typeSummary = fmt.Sprintf("%s - %d vCPU, %g GiB, %s network, %s", instanceType.Name, instanceType.VCPU, instanceType.MemoryGiB, instanceType.Network, instanceType.Architecture)
+	} else if state.String("instanceType") != "" {
+		typeSummary = state.String("instanceType") + " - no specs in the catalog"
+	}
+
+	pickerLabel := "Browse"
+	if state.Bool("showTypePicker") {
+		pickerLabel = "Close"
+	}
+
+	el.Div(
+		gr.CSS("form-group"),
+		el.Label(gr.Text("Instance Type")),
+		el.Div(
+			gr.CSS("input-group"),
+			el.Input(
+				attr.Type("text"),
+				attr.ClassName("form-control"),
+				attr.Value(typeSummary),
+				attr.ReadOnly(true),
+			),
+			el.Span(
+				gr.CSS("input-group-btn"),
+				el.Button(
+					evt.Click(i.toggleTypePicker).PreventDefault(),
+					gr.CSS("btn", "btn-default"),
+					gr.Text(pickerLabel),
+				),
+			),
+		),
+	).Modify(classEditForm)
+
+	if state.Bool("showTypePicker") {
+		gr.New(&InstanceTypePicker{}).CreateElement(gr.Props{
+			"value":      state.String("instanceType"),
+			"extraTypes": classOptions["instanceTypes"],
+			"onSelect":   i.selectInstanceType,
+		}).Modify(classEditForm)
+	}
 	SelectMultiple("Security Groups", "securityGroups", classOptions["securitygroups"], state.Interface("securityGroups"), i.storeSelect).Modify(classEditForm)
 	SelectMultiple("EBS Volumes", "ebsVolumes", classOptions["volumes"], state.Interface("ebsVolumes"), i.storeSelect).Modify(classEditForm)
 	SelectOne("Vpc", "vpc", classOptions["vpcs"], state.Interface("vpc"), i.storeSelect).Modify(classEditForm)
@@ -167,11 +209,27 @@ func (i InstanceClassForm) BuildClassForm(className string, optionsResp interfac
 	Checkbox("Public IP Address", "publicIpAddress", state.Bool("publicIpAddress"), i.storeValue).Modify(classEditForm)
 	SelectOne("AMI", "ami", classOptions["images"], state.Interface("ami"), i.storeSelect).Modify(classEditForm)
 	SelectOne("Key Name", "keyName", classOptions["keypairs"], state.Interface("keyName"), i.storeSelect).Modify(classEditForm)
-	Checkbox("EBS Optimized", "ebsOptimized", state.Bool("ebsOptimized"), i.storeValue).Modify(classEditForm)
+	switch instanceType.EbsOptimized {
+	case helpers.EbsOptimizedUnsupported:
+		DisabledCheckbox("EBS Optimized", false, instanceType.Name+" can't be EBS optimized").Modify(classEditForm)
+	case helpers.EbsOptimizedDefault:
+		DisabledCheckbox("EBS Optimized", true, instanceType.Name+" is always EBS optimized").Modify(classEditForm)
+	default:
+		Checkbox("EBS Optimized", "ebsOptimized", state.Bool("ebsOptimized"), i.storeValue).Modify(classEditForm)
+	}
 	Checkbox("Monitoring", "monitoring", state.Bool("monitoring"), i.storeValue).Modify(classEditForm)
 	SelectOne("Shutdown Behavior", "shutdownBehavior", shutdownBehaviors, state.Interface("shutdownBehavior"), i.storeSelect).Modify(classEditForm)
 	SelectOneMeta("IAM Instance Profile", "iamInstanceProfile", iamInstanceProfiles, iamInstanceProfilesMeta, state.Interface("iamInstanceProfile"), i.storeSelect).Modify(classEditForm)
-	TextArea("User Data", "userData", state.String("userData"), i.storeValue).Modify(classEditForm)
+	gr.New(&UserDataEditor{}).CreateElement(gr.Props{
+		"value":      state.String("userData"),
+		"ebsVolumes": state.Interface("ebsVolumes"),
+		"vars":       i.userDataVars(),
+		"onChange":   i.storeUserData,
+	}).Modify(classEditForm)
+
+	// Cost estimate
+	monthly, ok := helpers.InstanceMonthlyCost(state.String("instanceType"), costRegion(state.String("costRegion")))
+	CostEstimate(monthly, ok, "On demand, not including EBS volumes or data transfer", state.String("costRegion"), i.storeSelect).Modify(classEditForm)
 
 	classEditForm.Modify(classEdit)
 
@@ -187,14 +245,16 @@ func (i InstanceClassForm) BuildClassForm(className string, optionsResp interfac
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(i.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(i.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(i.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -202,7 +262,17 @@ func (i InstanceClassForm) BuildClassForm(className string, optionsResp interfac
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": i.deleteClass,
+			"onCancel":  i.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -219,12 +289,21 @@ func (i InstanceClassForm) doneButton(*gr.Event) {
 }
 
 func (i InstanceClassForm) saveButton(*gr.Event) {
-	i.SetState(gr.State{"querying": true, "step": 2})
+	// The user data is saved as written, the size that matters is with the {{variables}} filled in
+	if raw, _ := helpers.UserDataSizes(userDataPreview(i.State().String("userData"), i.userDataVars())); raw > helpers.UserDataLimit {
+		i.SetState(gr.State{"error": fmt.Sprintf("User data is over the %d byte limit", helpers.UserDataLimit)})
+		return
+	}
+
+	i.SetState(gr.State{"querying": true, "step": 2, "error": ""})
 
 	cfg := make(map[string]interface{})
 	for key, _ := range i.State() {
 		cfg[key] = i.State().Interface(key)
 	}
+	delete(cfg, "costRegion")
+	delete(cfg, "showTypePicker")
+	cfg["ebsOptimized"] = helpers.NormalizeEbsOptimized(i.State().String("instanceType"), i.State().Bool("ebsOptimized"))
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + i.Props().String("apiType") + "/name/" + i.Props().String("className")
@@ -245,7 +324,15 @@ func (i InstanceClassForm) saveButton(*gr.Event) {
 }
 
 func (i InstanceClassForm) deleteButton(*gr.Event) {
-	i.SetState(gr.State{"querying": true})
+	i.SetState(gr.State{"confirmDelete": true})
+}
+
+func (i InstanceClassForm) cancelDelete() {
+	i.SetState(gr.State{"confirmDelete": false})
+}
+
+func (i InstanceClassForm) deleteClass() {
+	i.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + i.Props().String("apiType") + "/name/" + i.Props().String("className")
@@ -282,6 +369,31 @@ func (i InstanceClassForm) storeValue(event *gr.Event) {
 	}
 }
 
+// userDataVars are the values of the user data {{variables}}, from the class being edited
+func (i InstanceClassForm) userDataVars() map[string]string {
+	state := i.State()
+	return map[string]string{
+		"class":        i.Props().String("className"),
+		"instanceType": state.String("instanceType"),
+		"vpc":          state.String("vpc"),
+		"subnet":       state.String("subnet"),
+		"keyName":      state.String("keyName"),
+	}
+}
+
+func (i InstanceClassForm) storeUserData(userData string) {
+	i.SetState(gr.State{"userData": userData})
+}
+
+func (i InstanceClassForm) toggleTypePicker(*gr.Event) {
+	i.SetState(gr.State{"showTypePicker": !i.State().Bool("showTypePicker")})
+}
+
+func (i InstanceClassForm) selectInstanceType(name string) {
+	// Keep ebsOptimized in line with what the new type supports
+	i.SetState(gr.State{"instanceType": name, "showTypePicker": false, "ebsOptimized": helpers.NormalizeEbsOptimized(name, i.State().Bool("ebsOptimized"))})
+}
+
 func (i InstanceClassForm) storeSelect(key string, val interface{}) {
 	switch value := val.(type) {
 
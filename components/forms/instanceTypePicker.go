@@ -0,0 +1,138 @@
+package forms
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// InstanceTypePicker is a searchable, filterable table of the instance type catalog
+type InstanceTypePicker struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (i InstanceTypePicker) GetInitialState() gr.State {
+	return gr.State{"search": "", "category": "", "architecture": "", "minVcpu": 0, "minMemory": 0}
+}
+
+func (i InstanceTypePicker) Render() gr.Component {
+
+	state := i.State()
+	props := i.Props()
+
+	catalog := helpers.InstanceTypeCatalog(stringList(props.Interface("extraTypes")))
+	selected := props.String("value")
+
+	picker := el.Div(gr.CSS("instance-type-picker", "well"))
+
+	// Filters
+	filters := el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-4"),
+			TextField("Search", "search", state.String("search"), i.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-3"),
+			SelectOne("Category", "category", helpers.InstanceCategories, state.Interface("category"), i.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-1"),
+			NumberField("Min vCPU", "minVcpu", state.Int("minVcpu"), i.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			NumberField("Min Memory (GiB)", "minMemory", state.Int("minMemory"), i.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			SelectOne("Architecture", "architecture", helpers.Architectures, state.Interface("architecture"), i.storeSelect),
+		),
+	)
+	el.Form(evt.KeyDown(DisableEnter), filters).Modify(picker)
+
+	// Matches
+	search := strings.ToLower(strings.TrimSpace(state.String("search")))
+	tBody := el.TableBody()
+	matches := 0
+
+	for _, instanceType := range catalog {
+		if search != "" && !strings.Contains(strings.ToLower(instanceType.Name+" "+instanceType.Category+" "+instanceType.Network), search) {
+			continue
+		}
+		if category := state.String("category"); category != "" && instanceType.Known() && instanceType.Category != category {
+			continue
+		}
+		if architecture := state.String("architecture"); architecture != "" && instanceType.Known() && instanceType.Architecture != architecture {
+			continue
+		}
+		if instanceType.VCPU < state.Int("minVcpu") || instanceType.MemoryGiB < float64(state.Int("minMemory")) {
+			continue
+		}
+		matches++
+
+		vcpu, memory := "?", "?"
+		if instanceType.Known() {
+			vcpu = strconv.Itoa(instanceType.VCPU)
+			memory = strconv.FormatFloat(instanceType.MemoryGiB, 'f', -1, 64)
+		}
+
+		row := el.TableRow(
+			el.TableData(el.Code(gr.Text(instanceType.Name))),
+			el.TableData(gr.Text(instanceType.Category)),
+			el.TableData(gr.Text(vcpu)),
+			el.TableData(gr.Text(memory)),
+			el.TableData(gr.Text(instanceType.Network)),
+			el.TableData(gr.Text(instanceType.EbsOptimized)),
+			el.TableData(gr.Text(instanceType.Architecture)),
+			el.TableData(
+				el.Button(
+					evt.Click(i.selectType(instanceType.Name)).PreventDefault(),
+					gr.CSS("btn", "btn-primary", "btn-xs", "pull-right"),
+					gr.Text("Select"),
+				),
+			),
+		)
+		if instanceType.Name == selected {
+			gr.CSS("info").Modify(row)
+		}
+		row.Modify(tBody)
+	}
+
+	el.Div(
+		gr.CSS("instance-type-picker-table"),
+		el.Table(
+			gr.CSS("table", "table-condensed", "table-hover"),
+			el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Type", "Category", "vCPU", "Memory (GiB)", "Network", "EBS Optimized", "Architecture", ""})...)),
+			tBody,
+		),
+	).Modify(picker)
+
+	el.Small(gr.Text(strconv.Itoa(matches) + " of " + strconv.Itoa(len(catalog)) + " instance types")).Modify(picker)
+
+	return picker
+}
+
+func (i InstanceTypePicker) selectType(name string) func(*gr.Event) {
+	return func(*gr.Event) {
+		i.Props().Call("onSelect", name)
+	}
+}
+
+func (i InstanceTypePicker) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+
+	switch event.Target().Get("type").String() {
+	case "number":
+		i.SetState(gr.State{key: event.TargetValue().Int()})
+	default:
+		i.SetState(gr.State{key: event.TargetValue().String()})
+	}
+}
+
+func (i InstanceTypePicker) storeSelect(key string, val interface{}) {
+	if value, ok := val.(map[string]interface{}); ok {
+		i.SetState(gr.State{key: value["value"]})
+	} else {
+		i.SetState(gr.State{key: ""})
+	}
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
@@ -19,7 +20,7 @@ type KeyPairClassForm struct {
 
 // Implements the StateInitializer interface
 func (k KeyPairClassForm) GetInitialState() gr.State {
-	return gr.State{"querying": true, "error": "", "success": "", "step": 1}
+	return gr.State{"querying": true, "error": "", "success": "", "step": 1, "generateKeyType": "rsa", "privateKeyEditing": false}
 }
 
 // Implements the ComponentWillMount interface
@@ -31,8 +32,17 @@ func (k KeyPairClassForm) ComponentWillMount() {
 		json.Unmarshal(classJson, &class)
 	}
 
+	// The stored private key never enters the form, only whether there is one
+	privateKey, _ := class["privateKey"].(string)
+	delete(class, "privateKey")
+
 	k.SetState(class)
-	k.SetState(gr.State{"querying": true})
+	k.SetState(gr.State{"querying": true, "privateKeyStored": privateKey != ""})
+
+	// Only new classes without a key start out with an editable private key
+	if privateKey == "" && k.Props().Interface("newClass") != nil && k.Props().Bool("newClass") {
+		k.SetState(gr.State{"privateKeyEditing": true})
+	}
 
 	// Get our options for the form
 	go func() {
@@ -128,12 +138,93 @@ func (k KeyPairClassForm) BuildClassForm(className string, optionsResp interface
 		).Modify(classEditForm)
 	}
 
-	// TODO make these file uploads
+	// Generate
+	el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-8"),
+			SelectOne("Generate Key Type", "generateKeyType", helpers.KeyPairTypes, state.Interface("generateKeyType"), k.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-4"),
+			el.Label(gr.Text("\u00a0")),
+			k.generateKeyButton(),
+		),
+	).Modify(classEditForm)
+
+	// Public Key
+	FileField("Upload Public Key", "publicKey", ".pub,.pem,.txt", k.storeFile).Modify(classEditForm)
 	TextArea("Public Key", "publicKey", state.String("publicKey"), k.storeValue).Modify(classEditForm)
 
-	//if props.Interface("newClass") != nil && props.Bool("newClass") {
-	TextArea("Private Key", "privateKey", state.String("privateKey"), k.storeValue).Modify(classEditForm)
-	//}
+	if publicKey := state.String("publicKey"); publicKey != "" {
+		if pub, err := helpers.ParsePublicKey(publicKey); err != nil {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+				gr.Text(" - "+err.Error()),
+			).Modify(classEditForm)
+		} else {
+			fingerprints := helpers.Fingerprints(pub)
+			fingerprintList := el.DescriptionList(gr.CSS("dl-horizontal", "key-fingerprints"),
+				el.DefinitionTerm(gr.Text("Key Type")),
+				el.Description(gr.Text(fingerprints.Type)),
+			)
+			if fingerprints.MD5 != "" {
+				el.DefinitionTerm(gr.Text("MD5")).Modify(fingerprintList)
+				el.Description(gr.Text(fingerprints.MD5)).Modify(fingerprintList)
+			}
+			el.DefinitionTerm(gr.Text("SHA256")).Modify(fingerprintList)
+			el.Description(gr.Text(fingerprints.SHA256)).Modify(fingerprintList)
+
+			fingerprintList.Modify(classEditForm)
+		}
+	}
+
+	// Private Key, never loaded into the form and never echoed back once it has been generated
+	if state.Bool("privateKeyEditing") {
+		FileField("Upload Private Key", "privateKey", ".pem,.key", k.storeFile).Modify(classEditForm)
+		TextArea("Private Key", "privateKey", state.String("privateKey"), k.storeValue).Modify(classEditForm)
+		if state.Bool("privateKeyStored") {
+			el.Div(
+				gr.CSS("form-group"),
+				gr.Text("Leave blank to keep the stored private key "),
+				el.Button(
+					evt.Click(k.keepPrivateKey).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-xs"),
+					gr.Text("Cancel"),
+				),
+			).Modify(classEditForm)
+		}
+
+	} else {
+		privateKeyStatus := "No private key stored"
+		switch {
+		case state.String("privateKey") != "":
+			privateKeyStatus = "New private key ready to save (hidden)"
+		case state.Bool("privateKeyStored"):
+			privateKeyStatus = "Private key stored (hidden)"
+		}
+
+		el.Div(
+			gr.CSS("form-group"),
+			el.Label(gr.Text("Private Key")),
+			el.Div(
+				gr.Text(privateKeyStatus+" "),
+				el.Button(
+					evt.Click(k.replacePrivateKey).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-xs"),
+					gr.Text("Replace"),
+				),
+			),
+		).Modify(classEditForm)
+	}
+
+	if publicKey, privateKey := state.String("publicKey"), state.String("privateKey"); publicKey != "" && privateKey != "" && state.Bool("privateKeyEditing") {
+		if err := helpers.ValidateKeyPair(publicKey, privateKey); err != nil {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+				gr.Text(" - "+err.Error()),
+			).Modify(classEditForm)
+		}
+	}
 
 	classEditForm.Modify(classEdit)
 
@@ -149,14 +240,16 @@ func (k KeyPairClassForm) BuildClassForm(className string, optionsResp interface
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(k.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(k.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(k.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -164,7 +257,17 @@ func (k KeyPairClassForm) BuildClassForm(className string, optionsResp interface
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": k.deleteClass,
+			"onCancel":  k.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -183,31 +286,55 @@ func (k KeyPairClassForm) doneButton(*gr.Event) {
 func (k KeyPairClassForm) saveButton(*gr.Event) {
 	k.SetState(gr.State{"querying": true, "step": 2, "error": ""})
 
+	// Validate key input
+	pubKey := k.State().String("publicKey")
+	privKey := k.State().String("privateKey")
+
 	props := k.Props()
 	if props.Interface("newClass") != nil && props.Bool("newClass") {
-		// Validate key input
-		pubKey := k.State().String("privateKey")
-		privKey := k.State().String("publicKey")
-
-		if pubKey != "" && privKey == "" {
+		if privKey != "" && pubKey == "" {
 			k.SetState(gr.State{"querying": false, "error": "Please enter a Public Key, or leave the Private Key blank.", "step": 1})
 			return
 		}
 
-		if pubKey == "" && privKey != "" {
+		if privKey == "" && pubKey != "" {
 			k.SetState(gr.State{"querying": false, "error": "Please enter a Private Key, or leave the Public Key blank.", "step": 1})
 			return
 		}
 	}
 
+	if pubKey != "" && privKey != "" {
+		if err := helpers.ValidateKeyPair(pubKey, privKey); err != nil {
+			k.SetState(gr.State{"querying": false, "error": err.Error(), "step": 1})
+			return
+		}
+	}
+
 	cfg := make(map[string]interface{})
 	for key, _ := range k.State() {
 		cfg[key] = k.State().Interface(key)
 	}
+	delete(cfg, "privateKeyStored")
+
+	keepPrivateKey := privKey == "" && k.State().Bool("privateKeyStored")
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + k.Props().String("apiType") + "/name/" + k.Props().String("className")
 
+		// Carry the stored private key over, since the form never has it
+		if keepPrivateKey {
+			resp, err := helpers.GetAPI(endpoint)
+			if !k.IsMounted() {
+				return
+			}
+			if err != nil {
+				k.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint), "step": 1})
+				return
+			}
+			jsonParsed, _ := gabs.ParseJSON(resp)
+			cfg["privateKey"], _ = jsonParsed.S("class", "privateKey").Data().(string)
+		}
+
 		_, err := helpers.PutAPI(endpoint, cfg)
 		if !k.IsMounted() {
 			return
@@ -218,13 +345,22 @@ func (k KeyPairClassForm) saveButton(*gr.Event) {
 			return
 		}
 
-		k.SetState(gr.State{"querying": false, "success": "Class was saved", "error": ""})
+		stored := keepPrivateKey || privKey != ""
+		k.SetState(gr.State{"querying": false, "success": "Class was saved", "error": "", "privateKey": "", "privateKeyStored": stored, "privateKeyEditing": false})
 	}()
 
 }
 
 func (k KeyPairClassForm) deleteButton(*gr.Event) {
-	k.SetState(gr.State{"querying": true})
+	k.SetState(gr.State{"confirmDelete": true})
+}
+
+func (k KeyPairClassForm) cancelDelete() {
+	k.SetState(gr.State{"confirmDelete": false})
+}
+
+func (k KeyPairClassForm) deleteClass() {
+	k.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + k.Props().String("apiType") + "/name/" + k.Props().String("className")
@@ -261,6 +397,78 @@ func (k KeyPairClassForm) storeValue(event *gr.Event) {
 	}
 }
 
+func (k KeyPairClassForm) storeFile(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	file := event.Target().Get("files").Index(0)
+
+	go func() {
+		data, err := helpers.ReadFile(file)
+		if !k.IsMounted() {
+			return
+		}
+		if err != nil {
+			k.SetState(gr.State{"error": err.Error()})
+			return
+		}
+		k.SetState(gr.State{key: string(data), "error": ""})
+	}()
+}
+
+func (k KeyPairClassForm) generateKeyButton() *gr.Element {
+	if k.State().Bool("generating") {
+		return el.Button(
+			gr.CSS("btn", "btn-default", "btn-block"),
+			gr.Prop("disabled", true),
+			el.Italic(gr.CSS("fa", "fa-spinner", "fa-spin")),
+			gr.Text(" Generating..."),
+		)
+	}
+
+	return el.Button(
+		evt.Click(k.generateButton).PreventDefault(),
+		gr.CSS("btn", "btn-default", "btn-block"),
+		gr.Text("Generate Key Pair"),
+	)
+}
+
+func (k KeyPairClassForm) generateButton(*gr.Event) {
+	keyType := k.State().String("generateKeyType")
+	if keyType == "" {
+		keyType = "rsa"
+	}
+
+	k.SetState(gr.State{"generating": true, "error": "", "success": ""})
+
+	go func() {
+		// GopherJS is single threaded, generating holds the page for the second or two an rsa key takes. Sleeping first
+		// lets the browser paint the spinner before it does
+		time.Sleep(50 * time.Millisecond)
+
+		publicKey, privateKey, err := helpers.GenerateKeyPair(keyType)
+		if !k.IsMounted() {
+			return
+		}
+		if err != nil {
+			k.SetState(gr.State{"generating": false, "error": err.Error()})
+			return
+		}
+
+		// The private key is only handed over as a download
+		helpers.DownloadFile(k.Props().String("className")+".pem", "application/x-pem-file", []byte(privateKey))
+
+		k.SetState(gr.State{"generating": false, "publicKey": publicKey, "privateKey": privateKey, "privateKeyEditing": false, "error": "", "success": "Key pair generated, the private key has been downloaded"})
+	}()
+}
+
+// replacePrivateKey opens the private key fields, the stored key is kept unless a new one is entered
+func (k KeyPairClassForm) replacePrivateKey(*gr.Event) {
+	k.SetState(gr.State{"privateKeyEditing": true})
+}
+
+func (k KeyPairClassForm) keepPrivateKey(*gr.Event) {
+	k.SetState(gr.State{"privateKey": "", "privateKeyEditing": false})
+}
+
 func (k KeyPairClassForm) storeSelect(key string, val interface{}) {
 	switch value := val.(type) {
 
@@ -140,14 +140,16 @@ func (l LaunchConfigurationClassForm) BuildClassForm(className string, optionsRe
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(l.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(l.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(l.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -155,7 +157,17 @@ func (l LaunchConfigurationClassForm) BuildClassForm(className string, optionsRe
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": l.deleteClass,
+			"onCancel":  l.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -198,7 +210,15 @@ func (l LaunchConfigurationClassForm) saveButton(*gr.Event) {
 }
 
 func (l LaunchConfigurationClassForm) deleteButton(*gr.Event) {
-	l.SetState(gr.State{"querying": true})
+	l.SetState(gr.State{"confirmDelete": true})
+}
+
+func (l LaunchConfigurationClassForm) cancelDelete() {
+	l.SetState(gr.State{"confirmDelete": false})
+}
+
+func (l LaunchConfigurationClassForm) deleteClass() {
+	l.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + l.Props().String("apiType") + "/name/" + l.Props().String("className")
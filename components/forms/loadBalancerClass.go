@@ -3,6 +3,8 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
@@ -27,6 +29,8 @@ func (l LoadBalancerClassForm) GetInitialState() gr.State {
 		"crossZoneLoadBalancingEnabled": false,
 		"connectionDrainingEnabled":     false,
 		"accessLogEnabled":              false,
+		"certificateOptions":            []string{},
+		"certificateMeta":               map[string]string{},
 	}
 }
 
@@ -54,7 +58,10 @@ func (l LoadBalancerClassForm) ComponentWillMount() {
 	}
 
 	l.SetState(class)
-	l.SetState(gr.State{"querying": true})
+
+	// The path is kept as typed, and only built into the target when it loses focus or on save
+	healthCheckTarget, _ := class["healthCheckTarget"].(string)
+	l.SetState(gr.State{"querying": true, "healthCheckPath": ParseHealthCheckTarget(healthCheckTarget).Path})
 
 	// Get our options for the form
 	go func() {
@@ -70,6 +77,36 @@ func (l LoadBalancerClassForm) ComponentWillMount() {
 
 		l.SetState(gr.State{"classOptionsResp": resp, "querying": false})
 	}()
+
+	// Get our IAM / ACM certificates for HTTPS and SSL listeners
+	go func() {
+		endpoint := "//localhost:8081/api/assets/certificates"
+		resp, err := helpers.GetAPI(endpoint)
+		if !l.IsMounted() || err != nil {
+			return
+		}
+
+		var certificates []string
+		certificateMeta := make(map[string]string)
+
+		jsonParsed, _ := gabs.ParseJSON(resp)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			arn, _ := asset.S("arn").Data().(string)
+			if arn == "" {
+				continue
+			}
+			name, _ := asset.S("name").Data().(string)
+			domain, _ := asset.S("domainName").Data().(string)
+			source, _ := asset.S("source").Data().(string)
+
+			certificates = append(certificates, arn)
+			certificateMeta[arn] = strings.TrimSpace(source + " " + name + " " + domain)
+		}
+		sort.Strings(certificates)
+
+		l.SetState(gr.State{"certificateOptions": certificates, "certificateMeta": certificateMeta})
+	}()
 }
 
 func (l LoadBalancerClassForm) Render() gr.Component {
@@ -160,9 +197,46 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 		el.HorizontalRule(nil),
 	).Modify(classEditForm)
 
-	TextField("Target", "healthCheckTarget", state.String("healthCheckTarget"), l.storeValue).Modify(classEditForm)
+	// Target builder
+	target := ParseHealthCheckTarget(state.String("healthCheckTarget"))
+	targetRow := el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-3"),
+			SelectOne("Target Protocol", "protocol", healthCheckProtocols, target.Protocol, l.storeHealthCheckSelect),
+		),
+		el.Div(gr.CSS("col-sm-3"),
+			NumberField("Target Port", "port", target.Port, l.storeHealthCheckValue),
+		),
+	)
+	if target.HasPath() {
+		el.Div(gr.CSS("col-sm-6"),
+			TextFieldOnBlur("Target Path", "healthCheckPath", state.String("healthCheckPath"), l.storeValue, l.buildHealthCheckTarget),
+		).Modify(targetRow)
+	}
+	targetRow.Modify(classEditForm)
+
+	el.Div(
+		gr.CSS("form-group"),
+		el.Code(gr.Text(target.String())),
+	).Modify(classEditForm)
+
+	if problem := target.Validate(); problem != "" {
+		el.Div(
+			gr.CSS("invalid-message"),
+			el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+			gr.Text(" - "+problem),
+		).Modify(classEditForm)
+	}
+
 	NumberField("Timeout", "healthCheckTimeout", state.Int("healthCheckTimeout"), l.storeValue).Modify(classEditForm)
 	NumberField("Interval", "healthCheckInterval", state.Int("healthCheckInterval"), l.storeValue).Modify(classEditForm)
+
+	if problem := ValidateHealthCheckTiming(state.Int("healthCheckTimeout"), state.Int("healthCheckInterval")); problem != "" {
+		el.Div(
+			gr.CSS("invalid-message"),
+			el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+			gr.Text(" - "+problem),
+		).Modify(classEditForm)
+	}
 	NumberField("Unhealthy Threshold", "healthCheckUnhealthyThreshold", state.Int("healthCheckUnhealthyThreshold"), l.storeValue).Modify(classEditForm)
 	NumberField("Healthy Threshold", "healthCheckHealthyThreshold", state.Int("healthCheckHealthyThreshold"), l.storeValue).Modify(classEditForm)
 
@@ -194,20 +268,41 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 		TextField("S3 Bucket Prefix", "accessLogS3BucketPrefix", state.String("accessLogS3BucketPrefix"), l.storeValue).Modify(classEditForm)
 	}
 
+	listenerHeader := el.Header4(
+		gr.Text("Listeners"),
+		el.Button(
+			evt.Click(l.addListener).PreventDefault(),
+			gr.CSS("btn", "btn-primary", "btn-sm", "pull-right"),
+			gr.Text("New"),
+		),
+	)
+
+	// Presets
+	for i := len(listenerPresets) - 1; i >= 0; i-- {
+		el.Button(
+			evt.Click(l.addPreset(listenerPresets[i])).PreventDefault(),
+			gr.CSS("btn", "btn-default", "btn-sm", "pull-right"),
+			gr.Style("marginRight", "5px"),
+			gr.Text("+ "+listenerPresets[i].Name),
+		).Modify(listenerHeader)
+	}
+
 	el.Div(
 		el.Break(nil),
-		el.Header4(
-			gr.Text("Listeners"),
-			el.Button(
-				evt.Click(l.addListener).PreventDefault(),
-				gr.CSS("btn", "btn-primary", "btn-sm", "pull-right"),
-				gr.Text("New"),
-			),
-		),
+		listenerHeader,
 		el.HorizontalRule(nil),
 	).Modify(classEditForm)
 
 	listeners := state.Interface("loadBalancerListeners").([]interface{})
+	listenerProblems := ValidateListeners(listeners)
+
+	certificateOptions := stringList(state.Interface("certificateOptions"))
+	certificateMeta := make(map[string]string)
+	if meta, ok := state.Interface("certificateMeta").(map[string]interface{}); ok {
+		for arn, m := range meta {
+			certificateMeta[arn] = fmt.Sprint(m)
+		}
+	}
 
 	for index, listInf := range listeners {
 
@@ -220,7 +315,7 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 
 		el.Div(
 			gr.CSS("row"), el.Div(gr.CSS("col-sm-6"),
-				SelectOne("Protocol", "protocol", []string{"HTTP", "HTTPS", "TCP", "SSL"}, listener["protocol"], l.storeListenerSelect(index, listener)),
+				SelectOne("Protocol", "protocol", listenerProtocols, listener["protocol"], l.storeListenerSelect(index, listener)),
 			),
 			el.Div(gr.CSS("col-sm-6"),
 				NumberField("Load Balancer Port", "loadBalancerPort", listener["loadBalancerPort"], l.modifyListener(index, listener)),
@@ -229,13 +324,33 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 
 		el.Div(
 			gr.CSS("row"), el.Div(gr.CSS("col-sm-6"),
-				SelectOne("Instance Protocol", "instanceProtocol", []string{"HTTP", "HTTPS", "TCP", "SSL"}, listener["instanceProtocol"], l.storeListenerSelect(index, listener)),
+				SelectOne("Instance Protocol", "instanceProtocol", listenerProtocols, listener["instanceProtocol"], l.storeListenerSelect(index, listener)),
 			),
 			el.Div(gr.CSS("col-sm-6"),
 				NumberField("Instance Port", "instancePort", listener["instancePort"], l.modifyListener(index, listener)),
 			),
 		).Modify(listenerForm)
 
+		// Certificate and SSL policy for listeners terminating HTTPS / SSL
+		if ListenerNeedsCertificate(listener) {
+			el.Div(
+				gr.CSS("row"), el.Div(gr.CSS("col-sm-6"),
+					SelectOneMeta("SSL Certificate", "sslCertificateID", certificateOptions, certificateMeta, listener["sslCertificateID"], l.storeListenerSelect(index, listener)),
+				),
+				el.Div(gr.CSS("col-sm-6"),
+					SelectOne("SSL Policy", "sslPolicy", sslPolicies, listener["sslPolicy"], l.storeListenerSelect(index, listener)),
+				),
+			).Modify(listenerForm)
+		}
+
+		for _, problem := range listenerProblems[index] {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+				gr.Text(" - "+problem),
+			).Modify(listenerForm)
+		}
+
 		el.Div(
 			gr.CSS("btn-toolbar"),
 			el.Button(
@@ -252,6 +367,9 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 
 	}
 
+	// Cost estimate
+	CostEstimate(helpers.LoadBalancerMonthlyCost(costRegion(state.String("costRegion"))), true, "Load balancer hours, not including data processed", state.String("costRegion"), l.storeSelect).Modify(classEditForm)
+
 	classEditForm.Modify(classEdit)
 
 	buttons := el.Div(
@@ -266,14 +384,16 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(l.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(l.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(l.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -281,7 +401,17 @@ func (l LoadBalancerClassForm) BuildClassForm(className string, optionsResp inte
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": l.deleteClass,
+			"onCancel":  l.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -298,12 +428,32 @@ func (l LoadBalancerClassForm) doneButton(*gr.Event) {
 }
 
 func (l LoadBalancerClassForm) saveButton(*gr.Event) {
-	l.SetState(gr.State{"querying": true, "step": 2})
+	if listeners, ok := l.State().Interface("loadBalancerListeners").([]interface{}); ok && len(ValidateListeners(listeners)) > 0 {
+		l.SetState(gr.State{"error": "Please fix the listener problems before saving"})
+		return
+	}
+
+	target := l.healthCheckTarget()
+	if problem := target.Validate(); problem != "" {
+		l.SetState(gr.State{"error": problem})
+		return
+	}
+	if problem := ValidateHealthCheckTiming(l.State().Int("healthCheckTimeout"), l.State().Int("healthCheckInterval")); problem != "" {
+		l.SetState(gr.State{"error": problem})
+		return
+	}
+
+	l.SetState(gr.State{"querying": true, "step": 2, "error": ""})
 
 	cfg := make(map[string]interface{})
 	for key, _ := range l.State() {
 		cfg[key] = l.State().Interface(key)
 	}
+	delete(cfg, "certificateOptions")
+	delete(cfg, "certificateMeta")
+	delete(cfg, "costRegion")
+	delete(cfg, "healthCheckPath")
+	cfg["healthCheckTarget"] = target.String()
 
 	loadBalancerHealthCheck := make(map[string]interface{})
 	loadBalancerHealthCheck["healthCheckTarget"] = cfg["healthCheckTarget"]
@@ -343,7 +493,15 @@ func (l LoadBalancerClassForm) saveButton(*gr.Event) {
 }
 
 func (l LoadBalancerClassForm) deleteButton(*gr.Event) {
-	l.SetState(gr.State{"querying": true})
+	l.SetState(gr.State{"confirmDelete": true})
+}
+
+func (l LoadBalancerClassForm) cancelDelete() {
+	l.SetState(gr.State{"confirmDelete": false})
+}
+
+func (l LoadBalancerClassForm) deleteClass() {
+	l.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + l.Props().String("apiType") + "/name/" + l.Props().String("className")
@@ -402,6 +560,38 @@ func (l LoadBalancerClassForm) storeSelect(key string, val interface{}) {
 	}
 }
 
+// healthCheckTarget returns the target with the path as typed
+func (l LoadBalancerClassForm) healthCheckTarget() HealthCheckTarget {
+	target := ParseHealthCheckTarget(l.State().String("healthCheckTarget"))
+	if target.HasPath() {
+		target.Path = strings.TrimSpace(l.State().String("healthCheckPath"))
+	}
+	return target
+}
+
+// setHealthCheckTarget stores the target, and the path normalized the same way it will be saved
+func (l LoadBalancerClassForm) setHealthCheckTarget(target HealthCheckTarget) {
+	l.SetState(gr.State{"healthCheckTarget": target.String(), "healthCheckPath": ParseHealthCheckTarget(target.String()).Path})
+}
+
+func (l LoadBalancerClassForm) buildHealthCheckTarget(*gr.Event) {
+	l.setHealthCheckTarget(l.healthCheckTarget())
+}
+
+func (l LoadBalancerClassForm) storeHealthCheckSelect(key string, val interface{}) {
+	target := l.healthCheckTarget()
+	if value, ok := val.(map[string]interface{}); ok {
+		target.Protocol = fmt.Sprint(value["value"])
+	}
+	l.setHealthCheckTarget(target)
+}
+
+func (l LoadBalancerClassForm) storeHealthCheckValue(event *gr.Event) {
+	target := l.healthCheckTarget()
+	target.Port = event.TargetValue().Int()
+	l.setHealthCheckTarget(target)
+}
+
 func (l LoadBalancerClassForm) modifyListener(index int, listener map[string]interface{}) func(*gr.Event) {
 	return func(event *gr.Event) {
 		key := event.Target().Get("name").String()
@@ -459,10 +649,10 @@ func (l LoadBalancerClassForm) addListener(*gr.Event) {
 	if ok {
 
 		newListener := make(map[string]interface{})
-		newListener["protocol"] = "tcp"
-		newListener["instanceProtocol"] = "tcp"
-		newListener["loadBalancerPort"] = 0
-		newListener["instancePort"] = 0
+		newListener["protocol"] = "TCP"
+		newListener["instanceProtocol"] = "TCP"
+		newListener["loadBalancerPort"] = 80
+		newListener["instancePort"] = 80
 
 		listeners = append([]interface{}{newListener}, listeners...)
 
@@ -472,6 +662,18 @@ func (l LoadBalancerClassForm) addListener(*gr.Event) {
 	println("addListener failed?")
 }
 
+func (l LoadBalancerClassForm) addPreset(preset listenerPreset) func(*gr.Event) {
+	return func(*gr.Event) {
+		listeners, ok := l.State().Interface("loadBalancerListeners").([]interface{})
+		if ok {
+			listeners = append([]interface{}{preset.Listener()}, listeners...)
+			l.SetState(gr.State{"loadBalancerListeners": listeners})
+			return
+		}
+		println("addPreset failed?")
+	}
+}
+
 func (l LoadBalancerClassForm) removeListener(event *gr.Event) {
 	index := event.Target().Get("id").Int()
 	listeners, ok := l.State().Interface("loadBalancerListeners").([]interface{})
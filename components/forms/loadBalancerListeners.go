@@ -0,0 +1,91 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	listenerProtocols = []string{"HTTP", "HTTPS", "TCP", "SSL"}
+
+	// sslPolicies are the predefined ELB security policies
+	sslPolicies = []string{
+		"ELBSecurityPolicy-2016-08",
+		"ELBSecurityPolicy-TLS-1-2-2017-01",
+		"ELBSecurityPolicy-TLS-1-1-2017-01",
+		"ELBSecurityPolicy-2015-05",
+		"ELBSecurityPolicy-2015-03",
+		"ELBSecurityPolicy-2015-02",
+	}
+
+	// listenerPresets are the common listener setups, HTTPS terminates at the load balancer and TCP passes TLS through to
+	// the instances
+	listenerPresets = []listenerPreset{
+		{Name: "HTTP", Protocol: "HTTP", LoadBalancerPort: 80, InstanceProtocol: "HTTP", InstancePort: 80},
+		{Name: "HTTPS", Protocol: "HTTPS", LoadBalancerPort: 443, InstanceProtocol: "HTTP", InstancePort: 80},
+		{Name: "TCP", Protocol: "TCP", LoadBalancerPort: 443, InstanceProtocol: "TCP", InstancePort: 443},
+	}
+)
+
+type listenerPreset struct {
+	Name             string
+	Protocol         string
+	LoadBalancerPort int
+	InstanceProtocol string
+	InstancePort     int
+}
+
+// Listener returns a new listener built from the preset
+func (p listenerPreset) Listener() map[string]interface{} {
+	listener := map[string]interface{}{
+		"protocol":         p.Protocol,
+		"loadBalancerPort": p.LoadBalancerPort,
+		"instanceProtocol": p.InstanceProtocol,
+		"instancePort":     p.InstancePort,
+	}
+	if ListenerNeedsCertificate(listener) {
+		listener["sslPolicy"] = sslPolicies[0]
+	}
+	return listener
+}
+
+// ListenerNeedsCertificate reports if the listener terminates HTTPS or SSL at the load balancer
+func ListenerNeedsCertificate(listener map[string]interface{}) bool {
+	protocol := strings.ToUpper(fmt.Sprint(listener["protocol"]))
+	return protocol == "HTTPS" || protocol == "SSL"
+}
+
+// ValidateListeners returns problems for each listener index: missing certificates, bad ports and load balancer
+// ports used by more than one listener
+func ValidateListeners(listeners []interface{}) map[int][]string {
+	problems := make(map[int][]string)
+	ports := make(map[int]int)
+
+	for index, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if ListenerNeedsCertificate(listener) {
+			if cert, _ := listener["sslCertificateID"].(string); cert == "" {
+				problems[index] = append(problems[index], fmt.Sprintf("%s listeners need an SSL certificate", strings.ToUpper(fmt.Sprint(listener["protocol"]))))
+			}
+		}
+
+		port := grantInt(listener["loadBalancerPort"])
+		if port < 1 || port > 65535 {
+			problems[index] = append(problems[index], "Load Balancer Port must be between 1 and 65535")
+		} else if other, ok := ports[port]; ok {
+			problems[index] = append(problems[index], fmt.Sprintf("Load Balancer Port %d is already used by listener #%d", port, other+1))
+		} else {
+			ports[port] = index
+		}
+
+		if instancePort := grantInt(listener["instancePort"]); instancePort < 1 || instancePort > 65535 {
+			problems[index] = append(problems[index], "Instance Port must be between 1 and 65535")
+		}
+	}
+
+	return problems
+}
@@ -138,14 +138,16 @@ func (r RSSWidgetForm) BuildWidgetForm(widgetName string, optionsResp interface{
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(r.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(r.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(r.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
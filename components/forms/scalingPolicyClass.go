@@ -138,14 +138,16 @@ func (s ScalingPolicyClassForm) BuildClassForm(className string, optionsResp int
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(s.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(s.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(s.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -153,7 +155,17 @@ func (s ScalingPolicyClassForm) BuildClassForm(className string, optionsResp int
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": s.deleteClass,
+			"onCancel":  s.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -196,7 +208,15 @@ func (s ScalingPolicyClassForm) saveButton(*gr.Event) {
 }
 
 func (s ScalingPolicyClassForm) deleteButton(*gr.Event) {
-	s.SetState(gr.State{"querying": true})
+	s.SetState(gr.State{"confirmDelete": true})
+}
+
+func (s ScalingPolicyClassForm) cancelDelete() {
+	s.SetState(gr.State{"confirmDelete": false})
+}
+
+func (s ScalingPolicyClassForm) deleteClass() {
+	s.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + s.Props().String("apiType") + "/name/" + s.Props().String("className")
@@ -3,6 +3,7 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,8 @@ import (
 
 var (
 	displayIpProtocols = []string{"tcp", "udp", "icmp", "all", "icmpv6"}
+
+	myIpEndpoint = "https://api.ipify.org?format=json"
 )
 
 type SecurityGroupClassForm struct {
@@ -28,6 +31,9 @@ func (s SecurityGroupClassForm) GetInitialState() gr.State {
 	return gr.State{"querying": false, "error": "", "success": "", "step": 1,
 		"classOptionsResp":    []interface{}{},
 		"securityGroupGrants": []interface{}{},
+		"cidrOptions":         []string{},
+		"tableView":           false,
+		"myIpQuerying":        false,
 	}
 }
 
@@ -57,6 +63,73 @@ func (s SecurityGroupClassForm) ComponentWillMount() {
 
 		s.SetState(gr.State{"classOptionsResp": resp, "querying": false})
 	}()
+
+	// Get CIDR suggestions from our vpc and subnet classes
+	go func() {
+		var cidrs []string
+
+		for _, classType := range []string{"vpcs", "subnets"} {
+			endpoint := "//localhost:8081/api/classes/" + classType
+			resp, err := helpers.GetAPI(endpoint)
+			if err != nil {
+				continue
+			}
+
+			jsonParsed, _ := gabs.ParseJSON(resp)
+			classes, _ := jsonParsed.S("classes").ChildrenMap()
+			for _, class := range classes {
+				if cidr, ok := class.S("cidr").Data().(string); ok && cidr != "" {
+					cidrs = appendUnique(cidrs, cidr)
+				}
+			}
+		}
+		sort.Strings(cidrs)
+
+		if !s.IsMounted() {
+			return
+		}
+
+		s.SetState(gr.State{"cidrOptions": cidrs})
+	}()
+}
+
+// suggestMyIp looks up the ip we are browsing from and adds it to the CIDR suggestions. It is only run when asked for,
+// since it sends our address to a third party
+func (s SecurityGroupClassForm) suggestMyIp(*gr.Event) {
+	s.SetState(gr.State{"myIpQuerying": true})
+
+	go func() {
+		resp, err := helpers.GetPublic(myIpEndpoint)
+		if !s.IsMounted() {
+			return
+		}
+		if err != nil {
+			s.SetState(gr.State{"myIpQuerying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", myIpEndpoint)})
+			return
+		}
+
+		jsonParsed, _ := gabs.ParseJSON(resp)
+		ip, ok := jsonParsed.S("ip").Data().(string)
+		if !ok || ip == "" {
+			s.SetState(gr.State{"myIpQuerying": false})
+			return
+		}
+
+		cidrs := []string{ip + "/32"}
+		if opts, ok := s.State().Interface("cidrOptions").([]interface{}); ok {
+			for _, opt := range opts {
+				if cidr, ok := opt.(string); ok {
+					cidrs = appendUnique(cidrs, cidr)
+				}
+			}
+		}
+
+		s.SetState(gr.State{"myIpQuerying": false, "cidrOptions": cidrs})
+	}()
+}
+
+func (s SecurityGroupClassForm) toggleTableView(*gr.Event) {
+	s.SetState(gr.State{"tableView": !s.State().Bool("tableView")})
 }
 
 func (s SecurityGroupClassForm) addGrant(*gr.Event) {
@@ -67,7 +140,7 @@ func (s SecurityGroupClassForm) addGrant(*gr.Event) {
 		newGrant["note"] = "New Grant"
 		newGrant["type"] = "ingress"
 		newGrant["fromPort"] = 0
-		newGrant["toPort"] = 0
+		newGrant["toPort"] = 65535
 		newGrant["ipProtocol"] = "tcp"
 
 		grants = append([]interface{}{newGrant}, grants...)
@@ -186,7 +259,7 @@ func (s SecurityGroupClassForm) modifyGrant(index int, grant map[string]interfac
 			if port == "all" {
 				grant["validPort"] = true
 				grant["fromPort"] = 0
-				grant["toPort"] = 0
+				grant["toPort"] = 65535
 
 				// ICMP/ICMPv4 ALL is -1, not 0
 				if grant["ipProtocol"] == "icmp" || grant["ipProtocol"] == "58" {
@@ -295,14 +368,38 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 
 	TextField("Description", "description", state.String("description"), s.storeValue).Modify(classEditForm)
 
+	tableViewLabel := "Table View"
+	if state.Bool("tableView") {
+		tableViewLabel = "Edit View"
+	}
+
+	myIpLabel := "Suggest My IP"
+	if state.Bool("myIpQuerying") {
+		myIpLabel = "Looking Up IP..."
+	}
+
 	el.Div(
 		el.Break(nil),
 		el.Header4(
 			gr.Text("Grants"),
-			el.Button(
-				evt.Click(s.addGrant).PreventDefault(),
-				gr.CSS("btn", "btn-primary", "btn-sm", "pull-right"),
-				gr.Text("New"),
+			el.Div(
+				gr.CSS("btn-toolbar", "pull-right"),
+				el.Button(
+					evt.Click(s.suggestMyIp).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-sm"),
+					gr.Prop("title", "Look up the ip you are browsing from with "+myIpEndpoint),
+					gr.Text(myIpLabel),
+				),
+				el.Button(
+					evt.Click(s.toggleTableView).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-sm"),
+					gr.Text(tableViewLabel),
+				),
+				el.Button(
+					evt.Click(s.addGrant).PreventDefault(),
+					gr.CSS("btn", "btn-primary", "btn-sm"),
+					gr.Text("New"),
+				),
 			),
 		),
 		el.HorizontalRule(nil),
@@ -317,9 +414,23 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 		classOptionsJson := jsonParsed.S("classOptions").Bytes()
 		json.Unmarshal(classOptionsJson, &classOptions)
 
+		var cidrOptions []string
+		if opts, ok := state.Interface("cidrOptions").([]interface{}); ok {
+			for _, opt := range opts {
+				if cidr, ok := opt.(string); ok {
+					cidrOptions = append(cidrOptions, cidr)
+				}
+			}
+		}
+
 		grants, ok := state.Interface("securityGroupGrants").([]interface{})
+		grantWarnings := AnalyzeGrants(grants)
 
-		if ok {
+		if ok && state.Bool("tableView") {
+
+			BuildGrantsTable(grants, grantWarnings).Modify(classEditForm)
+
+		} else if ok {
 
 			for index, g := range grants {
 
@@ -328,7 +439,7 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 				// Build the port value
 				if _, ok := grant["port"].(string); !ok {
 					if grant["fromPort"] == nil {
-						grant["port"] = "0"
+						grant["port"] = "all"
 					} else if grant["fromPort"] == grant["toPort"] {
 						grant["port"] = fmt.Sprint(grant["fromPort"])
 					} else {
@@ -336,7 +447,7 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 					}
 
 					// ICMP/ICMPv4 ALL is -1, not 0
-					if grant["port"] == "0" || (grant["port"] == "-1" && (grant["ipProtocol"] == "icmp" || grant["ipProtocol"] == "58")) {
+					if grant["port"] == "0-65535" || (grant["port"] == "-1" && (grant["ipProtocol"] == "icmp" || grant["ipProtocol"] == "58")) {
 						grant["port"] = "all"
 					}
 
@@ -392,7 +503,7 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 						CreateableSelectMultiple("Security Groups", "sourceSecurityGroupNames", classOptions["securitygroups"], grant["sourceSecurityGroupNames"], s.storeSelect(index, grant)),
 					),
 					el.Div(gr.CSS("col-sm-6"),
-						CreateableSelectMultiple("CIDR IPs", "cidrIPs", []string{ /* TODO */ }, grant["cidrIPs"], s.storeSelect(index, grant)),
+						CreateableSelectMultiple("CIDR IPs", "cidrIPs", cidrOptions, grant["cidrIPs"], s.storeSelect(index, grant)),
 					),
 				).Modify(grantForm)
 
@@ -407,6 +518,15 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 					}
 				}
 
+				// CIDR validation and rule analysis warnings
+				for _, warning := range grantWarnings[index] {
+					el.Div(
+						gr.CSS("invalid-message"),
+						el.Italic(gr.CSS("fa", "fa-exclamation-triangle")),
+						gr.Text(" - "+warning),
+					).Modify(validDiv)
+				}
+
 				el.Div(
 					gr.CSS("row"), el.Div(gr.CSS("col-sm-8"),
 						validDiv,
@@ -445,14 +565,16 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 		).Modify(buttons)
 
 		// Save
-		el.Button(
-			evt.Click(s.saveButton).PreventDefault(),
-			gr.CSS("btn", "btn-primary"),
-			gr.Text("Save"),
-		).Modify(buttons)
+		if helpers.CanEdit() {
+			el.Button(
+				evt.Click(s.saveButton).PreventDefault(),
+				gr.CSS("btn", "btn-primary"),
+				gr.Text("Save"),
+			).Modify(buttons)
+		}
 
 		// Delete
-		if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+		if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 			el.Button(
 				evt.Click(s.deleteButton).PreventDefault(),
 				gr.CSS("btn", "btn-danger", "pull-right"),
@@ -460,7 +582,17 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 			).Modify(buttons)
 		}
 
-		buttons.Modify(classEdit)
+		// Delete confirmation
+		if state.Bool("confirmDelete") {
+			gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+				"apiType":   props.String("apiType"),
+				"className": props.String("className"),
+				"onConfirm": s.deleteClass,
+				"onCancel":  s.cancelDelete,
+			}).Modify(classEdit)
+		} else {
+			buttons.Modify(classEdit)
+		}
 
 	}
 
@@ -468,6 +600,73 @@ func (s SecurityGroupClassForm) BuildClassForm(className string, optionsResp int
 
 }
 
+// BuildGrantsTable renders a compact, read only table of every grant
+func BuildGrantsTable(grants []interface{}, grantWarnings map[int][]string) *gr.Element {
+
+	tBody := el.TableBody()
+
+	for index, g := range grants {
+		grant, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		protocol, ok := grant["displayIpProtocol"].(string)
+		if !ok {
+			switch protocol = fmt.Sprint(grant["ipProtocol"]); protocol {
+			case "-1":
+				protocol = "all"
+			case "58":
+				protocol = "icmpv6"
+			}
+		}
+
+		port, ok := grant["port"].(string)
+		if !ok {
+			if fromPort, toPort := GrantPortRange(grant); fromPort == 0 && toPort == 65535 || grantInt(grant["fromPort"]) == -1 {
+				port = "all"
+			} else if fromPort == toPort {
+				port = fmt.Sprint(fromPort)
+			} else {
+				port = fmt.Sprintf("%d-%d", fromPort, toPort)
+			}
+		}
+
+		grantType, _ := grant["type"].(string)
+		note, _ := grant["note"].(string)
+
+		sources := append(stringList(grant["cidrIPs"]), stringList(grant["sourceSecurityGroupNames"])...)
+
+		warnings := el.TableData()
+		for _, warning := range grantWarnings[index] {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-triangle")),
+				gr.Text(" "+warning),
+			).Modify(warnings)
+		}
+
+		el.TableRow(
+			el.TableData(gr.Text(fmt.Sprint(index+1))),
+			el.TableData(gr.Text(grantType)),
+			el.TableData(gr.Text(protocol)),
+			el.TableData(gr.Text(port)),
+			el.TableData(gr.Text(strings.Join(sources, ", "))),
+			el.TableData(gr.Text(note)),
+			warnings,
+		).Modify(tBody)
+	}
+
+	table := el.Table(
+		gr.CSS("table", "table-striped", "table-condensed"),
+		gr.Style("width", "100%"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"#", "Type", "Protocol", "Port", "Sources", "Note", "Warnings"})...)))
+
+	tBody.Modify(table)
+
+	return table
+}
+
 func (s SecurityGroupClassForm) backButton(*gr.Event) {
 	s.SetState(gr.State{"success": ""})
 	s.Props().Call("backButton")
@@ -505,7 +704,15 @@ func (s SecurityGroupClassForm) saveButton(*gr.Event) {
 }
 
 func (s SecurityGroupClassForm) deleteButton(*gr.Event) {
-	s.SetState(gr.State{"querying": true})
+	s.SetState(gr.State{"confirmDelete": true})
+}
+
+func (s SecurityGroupClassForm) cancelDelete() {
+	s.SetState(gr.State{"confirmDelete": false})
+}
+
+func (s SecurityGroupClassForm) deleteClass() {
+	s.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + s.Props().String("apiType") + "/name/" + s.Props().String("className")
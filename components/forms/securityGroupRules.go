@@ -0,0 +1,262 @@
+package forms
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+var (
+	// sensitivePorts should never be open to the whole internet
+	sensitivePorts = map[int]string{
+		21:    "FTP",
+		22:    "SSH",
+		23:    "Telnet",
+		445:   "SMB",
+		1433:  "MSSQL",
+		3306:  "MySQL",
+		3389:  "RDP",
+		5432:  "PostgreSQL",
+		5900:  "VNC",
+		6379:  "Redis",
+		9200:  "Elasticsearch",
+		11211: "Memcached",
+		27017: "MongoDB",
+	}
+
+	openCidrs = []string{"0.0.0.0/0", "::/0"}
+)
+
+// grantRule is a single cidr source of a grant, flattened for comparison
+type grantRule struct {
+	Index    int
+	Type     string
+	Protocol string
+	FromPort int
+	ToPort   int
+	Cidr     string
+	Net      *net.IPNet
+}
+
+// ValidateCidr returns a description of what's wrong with a cidr, or an empty string if it is valid
+func ValidateCidr(cidr string) string {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return cidr + " is not a valid CIDR"
+	}
+	if !ip.Equal(ipNet.IP) {
+		return cidr + " has host bits set, did you mean " + ipNet.String() + "?"
+	}
+	return ""
+}
+
+// GrantProtocol returns the protocol of a grant by name, numbers are translated and "-1" is all traffic
+func GrantProtocol(grant map[string]interface{}) string {
+	switch protocol := strings.ToLower(fmt.Sprint(grant["ipProtocol"])); protocol {
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	case "58":
+		return "icmpv6"
+	case "all":
+		return "-1"
+	default:
+		return protocol
+	}
+}
+
+// GrantPortRange returns the ports a grant allows. All traffic is every port, and for icmp it is the icmp type, where
+// -1 is every type
+func GrantPortRange(grant map[string]interface{}) (int, int) {
+	fromPort, toPort := grantInt(grant["fromPort"]), grantInt(grant["toPort"])
+
+	switch GrantProtocol(grant) {
+	case "-1":
+		return 0, 65535
+	case "icmp", "icmpv6":
+		if fromPort == -1 {
+			return 0, 255
+		}
+		return fromPort, fromPort
+	}
+
+	if toPort < fromPort {
+		return toPort, fromPort
+	}
+	return fromPort, toPort
+}
+
+// grantRules flattens grants into one rule per valid cidr
+func grantRules(grants []interface{}) []grantRule {
+	var rules []grantRule
+
+	for index, g := range grants {
+		grant, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		fromPort, toPort := GrantPortRange(grant)
+
+		for _, cidr := range stringList(grant["cidrIPs"]) {
+			_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				continue
+			}
+			rules = append(rules, grantRule{
+				Index:    index,
+				Type:     fmt.Sprint(grant["type"]),
+				Protocol: GrantProtocol(grant),
+				FromPort: fromPort,
+				ToPort:   toPort,
+				Cidr:     ipNet.String(),
+				Net:      ipNet,
+			})
+		}
+	}
+
+	return rules
+}
+
+// AnalyzeGrants returns warnings for each grant index: invalid cidrs, duplicates, shadowed and overlapping rules, and
+// sensitive ports open to the internet
+func AnalyzeGrants(grants []interface{}) map[int][]string {
+	warnings := make(map[int][]string)
+
+	// Invalid cidrs and internet exposure
+	for index, g := range grants {
+		grant, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, cidr := range stringList(grant["cidrIPs"]) {
+			if msg := ValidateCidr(cidr); msg != "" {
+				warnings[index] = append(warnings[index], msg)
+			}
+		}
+
+		if fmt.Sprint(grant["type"]) != "ingress" || !hasOpenCidr(stringList(grant["cidrIPs"])) {
+			continue
+		}
+		if protocol := GrantProtocol(grant); protocol == "icmp" || protocol == "icmpv6" {
+			continue
+		}
+
+		fromPort, toPort := GrantPortRange(grant)
+		if fromPort == 0 && toPort == 65535 {
+			warnings[index] = append(warnings[index], "All ports are open to the internet")
+			continue
+		}
+
+		var exposed []int
+		for port := range sensitivePorts {
+			if port >= fromPort && port <= toPort {
+				exposed = append(exposed, port)
+			}
+		}
+		sort.Ints(exposed)
+		for _, port := range exposed {
+			warnings[index] = append(warnings[index], fmt.Sprintf("%s (port %d) is open to the internet", sensitivePorts[port], port))
+		}
+	}
+
+	// Compare every rule against every other rule
+	rules := grantRules(grants)
+	for i, a := range rules {
+		for j, b := range rules {
+			if i == j || a.Type != b.Type || !protocolCovers(b.Protocol, a.Protocol) && !protocolCovers(a.Protocol, b.Protocol) {
+				continue
+			}
+			if a.ToPort < b.FromPort || b.ToPort < a.FromPort || !a.Net.Contains(b.Net.IP) && !b.Net.Contains(a.Net.IP) {
+				continue
+			}
+
+			aOnes, _ := a.Net.Mask.Size()
+			bOnes, _ := b.Net.Mask.Size()
+
+			// Rules from the same grant share their protocol and ports, so only the cidrs can clash
+			where := fmt.Sprintf("in grant #%d", b.Index+1)
+			if a.Index == b.Index {
+				where = "in this grant"
+			}
+
+			switch {
+			case a.Cidr == b.Cidr && a.Protocol == b.Protocol && a.FromPort == b.FromPort && a.ToPort == b.ToPort:
+				if i < j && a.Index == b.Index {
+					warnings[b.Index] = appendUnique(warnings[b.Index], fmt.Sprintf("%s is listed more than once in this grant", b.Cidr))
+				} else if i < j {
+					warnings[b.Index] = appendUnique(warnings[b.Index], fmt.Sprintf("%s duplicates grant #%d", b.Cidr, a.Index+1))
+				}
+
+			case protocolCovers(b.Protocol, a.Protocol) && bOnes <= aOnes && b.FromPort <= a.FromPort && b.ToPort >= a.ToPort:
+				warnings[a.Index] = appendUnique(warnings[a.Index], fmt.Sprintf("%s is shadowed by %s %s", a.Cidr, b.Cidr, where))
+
+			case i < j && !(protocolCovers(a.Protocol, b.Protocol) && aOnes <= bOnes && a.FromPort <= b.FromPort && a.ToPort >= b.ToPort):
+				warnings[a.Index] = appendUnique(warnings[a.Index], fmt.Sprintf("%s overlaps %s %s", a.Cidr, b.Cidr, where))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// protocolCovers reports if traffic allowed by protocol a includes everything allowed by protocol b
+func protocolCovers(a, b string) bool {
+	return a == "-1" || a == b
+}
+
+func hasOpenCidr(cidrs []string) bool {
+	for _, cidr := range cidrs {
+		for _, open := range openCidrs {
+			if strings.TrimSpace(cidr) == open {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, l := range list {
+		if l == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// stringList reads a list of strings out of a grant or state value
+func stringList(v interface{}) []string {
+	var strs []string
+
+	switch values := v.(type) {
+	case []string:
+		strs = values
+	case []interface{}:
+		for _, value := range values {
+			if str, ok := value.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+	}
+
+	return strs
+}
+
+// grantInt reads a number out of a grant value, json numbers come back as floats
+func grantInt(v interface{}) int {
+	switch value := v.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	case int64:
+		return int(value)
+	}
+	return 0
+}
@@ -0,0 +1,221 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testGrant builds a grant the way it comes back from the api, with json numbers
+func testGrant(grantType, protocol string, fromPort, toPort float64, cidrs ...string) map[string]interface{} {
+	cidrIPs := make([]interface{}, len(cidrs))
+	for i, cidr := range cidrs {
+		cidrIPs[i] = cidr
+	}
+	return map[string]interface{}{"type": grantType, "ipProtocol": protocol, "fromPort": fromPort, "toPort": toPort, "cidrIPs": cidrIPs}
+}
+
+func TestValidateCidr(t *testing.T) {
+	tests := []struct {
+		cidr  string
+		valid bool
+	}{
+		{cidr: "10.0.0.0/16", valid: true},
+		{cidr: " 0.0.0.0/0 ", valid: true},
+		{cidr: "::/0", valid: true},
+		{cidr: "10.0.0.5/32", valid: true},
+		{cidr: "10.0.0.1/16"},
+		{cidr: "10.0.0/24"},
+		{cidr: "10.0.0.0"},
+		{cidr: ""},
+	}
+
+	for _, test := range tests {
+		if problem := ValidateCidr(test.cidr); (problem == "") != test.valid {
+			t.Errorf("ValidateCidr(%q) = %q, want valid %t", test.cidr, problem, test.valid)
+		}
+	}
+}
+
+func TestGrantProtocol(t *testing.T) {
+	tests := []struct {
+		protocol interface{}
+		want     string
+	}{
+		{protocol: "tcp", want: "tcp"},
+		{protocol: "TCP", want: "tcp"},
+		{protocol: "6", want: "tcp"},
+		{protocol: "17", want: "udp"},
+		{protocol: "1", want: "icmp"},
+		{protocol: "58", want: "icmpv6"},
+		{protocol: "all", want: "-1"},
+		{protocol: "-1", want: "-1"},
+		{protocol: float64(-1), want: "-1"},
+	}
+
+	for _, test := range tests {
+		if got := GrantProtocol(map[string]interface{}{"ipProtocol": test.protocol}); got != test.want {
+			t.Errorf("GrantProtocol(%v) = %q, want %q", test.protocol, got, test.want)
+		}
+	}
+}
+
+func TestGrantPortRange(t *testing.T) {
+	tests := []struct {
+		protocol         string
+		fromPort, toPort float64
+		wantFrom, wantTo int
+	}{
+		{protocol: "tcp", fromPort: 22, toPort: 22, wantFrom: 22, wantTo: 22},
+		{protocol: "tcp", fromPort: 443, toPort: 80, wantFrom: 80, wantTo: 443},
+		{protocol: "tcp", fromPort: 0, toPort: 65535, wantFrom: 0, wantTo: 65535},
+		{protocol: "tcp", fromPort: 0, toPort: 0, wantFrom: 0, wantTo: 0},
+		{protocol: "udp", fromPort: 53, toPort: 53, wantFrom: 53, wantTo: 53},
+		{protocol: "-1", fromPort: -1, toPort: -1, wantFrom: 0, wantTo: 65535},
+		{protocol: "all", fromPort: 0, toPort: 0, wantFrom: 0, wantTo: 65535},
+		{protocol: "icmp", fromPort: 8, toPort: -1, wantFrom: 8, wantTo: 8},
+		{protocol: "icmp", fromPort: -1, toPort: -1, wantFrom: 0, wantTo: 255},
+		{protocol: "58", fromPort: 128, toPort: 0, wantFrom: 128, wantTo: 128},
+	}
+
+	for _, test := range tests {
+		fromPort, toPort := GrantPortRange(testGrant("ingress", test.protocol, test.fromPort, test.toPort))
+		if fromPort != test.wantFrom || toPort != test.wantTo {
+			t.Errorf("GrantPortRange(%s %v-%v) = %d-%d, want %d-%d", test.protocol, test.fromPort, test.toPort, fromPort, toPort, test.wantFrom, test.wantTo)
+		}
+	}
+
+	if fromPort, toPort := GrantPortRange(map[string]interface{}{"ipProtocol": "tcp", "fromPort": 80, "toPort": 81}); fromPort != 80 || toPort != 81 {
+		t.Errorf("GrantPortRange() with int ports = %d-%d, want 80-81", fromPort, toPort)
+	}
+}
+
+func TestAnalyzeGrants(t *testing.T) {
+	tests := []struct {
+		name   string
+		grants []map[string]interface{}
+		want   map[int][]string
+	}{
+		{
+			name:   "clean",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "0.0.0.0/0"), testGrant("ingress", "tcp", 22, 22, "10.0.0.0/16")},
+		},
+		{
+			name:   "invalid cidr",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.0/24")},
+			want:   map[int][]string{0: {"10.0.0/24 is not a valid CIDR"}},
+		},
+		{
+			name:   "host bits",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.0.1/16")},
+			want:   map[int][]string{0: {"10.0.0.1/16 has host bits set, did you mean 10.0.0.0/16?"}},
+		},
+		{
+			name:   "sensitive port",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 22, 22, "0.0.0.0/0")},
+			want:   map[int][]string{0: {"SSH (port 22) is open to the internet"}},
+		},
+		{
+			name:   "sensitive port range",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 20, 23, "0.0.0.0/0")},
+			want:   map[int][]string{0: {"FTP (port 21) is open to the internet", "SSH (port 22) is open to the internet", "Telnet (port 23) is open to the internet"}},
+		},
+		{
+			name:   "sensitive port over ipv6",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 3389, 3389, "::/0")},
+			want:   map[int][]string{0: {"RDP (port 3389) is open to the internet"}},
+		},
+		{
+			name:   "egress is not exposure",
+			grants: []map[string]interface{}{testGrant("egress", "tcp", 22, 22, "0.0.0.0/0")},
+		},
+		{
+			name:   "icmp is not exposure",
+			grants: []map[string]interface{}{testGrant("ingress", "icmp", -1, -1, "0.0.0.0/0")},
+		},
+		{
+			name:   "all traffic",
+			grants: []map[string]interface{}{testGrant("ingress", "-1", -1, -1, "0.0.0.0/0")},
+			want:   map[int][]string{0: {"All ports are open to the internet"}},
+		},
+		{
+			name:   "all traffic by name",
+			grants: []map[string]interface{}{testGrant("ingress", "all", 0, 0, "0.0.0.0/0")},
+			want:   map[int][]string{0: {"All ports are open to the internet"}},
+		},
+		{
+			name:   "all ports",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 0, 65535, "0.0.0.0/0")},
+			want:   map[int][]string{0: {"All ports are open to the internet"}},
+		},
+		{
+			name:   "duplicate grants",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.0.0/16"), testGrant("ingress", "6", 443, 443, "10.0.0.0/16")},
+			want:   map[int][]string{1: {"10.0.0.0/16 duplicates grant #1"}},
+		},
+		{
+			name:   "duplicate in a grant",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.0.0/16", "10.0.0.0/16")},
+			want:   map[int][]string{0: {"10.0.0.0/16 is listed more than once in this grant"}},
+		},
+		{
+			name:   "shadowed",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.1.0/24"), testGrant("ingress", "tcp", 0, 65535, "10.0.0.0/16")},
+			want:   map[int][]string{0: {"10.0.1.0/24 is shadowed by 10.0.0.0/16 in grant #2"}},
+		},
+		{
+			name:   "shadowed by all traffic",
+			grants: []map[string]interface{}{testGrant("ingress", "udp", 53, 53, "10.0.0.0/24"), testGrant("ingress", "-1", -1, -1, "10.0.0.0/16")},
+			want:   map[int][]string{0: {"10.0.0.0/24 is shadowed by 10.0.0.0/16 in grant #2"}},
+		},
+		{
+			name:   "shadowed in a grant",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.1.0/24", "10.0.0.0/16")},
+			want:   map[int][]string{0: {"10.0.1.0/24 is shadowed by 10.0.0.0/16 in this grant"}},
+		},
+		{
+			name:   "icmp type shadowed by every type",
+			grants: []map[string]interface{}{testGrant("ingress", "icmp", 8, -1, "10.0.0.0/24"), testGrant("ingress", "icmp", -1, -1, "10.0.0.0/16")},
+			want:   map[int][]string{0: {"10.0.0.0/24 is shadowed by 10.0.0.0/16 in grant #2"}},
+		},
+		{
+			name:   "different icmp types",
+			grants: []map[string]interface{}{testGrant("ingress", "icmp", 8, -1, "10.0.0.0/16"), testGrant("ingress", "icmp", 0, -1, "10.0.0.0/16")},
+		},
+		{
+			name:   "overlapping ports",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 80, 443, "10.0.0.0/16"), testGrant("ingress", "tcp", 400, 500, "10.0.1.0/24")},
+			want:   map[int][]string{0: {"10.0.0.0/16 overlaps 10.0.1.0/24 in grant #2"}},
+		},
+		{
+			name:   "overlapping cidrs",
+			grants: []map[string]interface{}{testGrant("ingress", "tcp", 443, 443, "10.0.1.0/24"), testGrant("ingress", "tcp", 80, 80, "10.0.0.0/16"), testGrant("ingress", "tcp", 80, 443, "10.0.1.128/25")},
+			want:   map[int][]string{0: {"10.0.1.0/24 overlaps 10.0.1.128/25 in grant #3"}, 1: {"10.0.0.0/16 overlaps 10.0.1.128/25 in grant #3"}},
+		},
+		{
+			name: "no clash across types, protocols or ports",
+			grants: []map[string]interface{}{
+				testGrant("ingress", "tcp", 443, 443, "10.0.0.0/16"),
+				testGrant("egress", "tcp", 443, 443, "10.0.0.0/16"),
+				testGrant("ingress", "udp", 443, 443, "10.0.0.0/16"),
+				testGrant("ingress", "tcp", 80, 80, "10.0.0.0/16"),
+				testGrant("ingress", "icmp", 3, 3, "10.0.0.0/16"),
+				testGrant("ingress", "tcp", 443, 443, "10.1.0.0/16"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		grants := make([]interface{}, len(test.grants))
+		for i, grant := range test.grants {
+			grants[i] = grant
+		}
+
+		got := AnalyzeGrants(grants)
+		if len(got) == 0 && len(test.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: AnalyzeGrants() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -179,14 +179,16 @@ func (s SnapshotClassForm) BuildClassForm(className string, optionsResp interfac
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(s.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(s.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(s.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -194,7 +196,17 @@ func (s SnapshotClassForm) BuildClassForm(className string, optionsResp interfac
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": s.deleteClass,
+			"onCancel":  s.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -237,7 +249,15 @@ func (s SnapshotClassForm) saveButton(*gr.Event) {
 }
 
 func (s SnapshotClassForm) deleteButton(*gr.Event) {
-	s.SetState(gr.State{"querying": true})
+	s.SetState(gr.State{"confirmDelete": true})
+}
+
+func (s SnapshotClassForm) cancelDelete() {
+	s.SetState(gr.State{"confirmDelete": false})
+}
+
+func (s SnapshotClassForm) deleteClass() {
+	s.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + s.Props().String("apiType") + "/name/" + s.Props().String("className")
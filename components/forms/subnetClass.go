@@ -120,6 +120,33 @@ func (s SubnetClassForm) BuildClassForm(className string, optionsResp interface{
 
 	TextField("CIDR", "cidr", state.String("cidr"), s.storeValue).Modify(classEditForm)
 
+	if cidr := state.String("cidr"); cidr != "" {
+		if _, err := helpers.ParseCidrBlock(cidr); err != nil {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+				gr.Text(" - "+err.Error()),
+			).Modify(classEditForm)
+		}
+	}
+
+	plannerLabel := "Plan CIDR"
+	if state.Bool("showPlanner") {
+		plannerLabel = "Hide Planner"
+	}
+	el.Button(
+		evt.Click(s.togglePlanner).PreventDefault(),
+		gr.CSS("btn", "btn-default", "btn-xs"),
+		gr.Text(plannerLabel),
+	).Modify(classEditForm)
+
+	if state.Bool("showPlanner") {
+		gr.New(&CidrPlanner{}).CreateElement(gr.Props{
+			"className": props.String("className"),
+			"onSelect":  s.selectCidr,
+		}).Modify(classEditForm)
+	}
+
 	el.Div(
 		el.Break(nil),
 		el.Header4(
@@ -175,14 +202,16 @@ func (s SubnetClassForm) BuildClassForm(className string, optionsResp interface{
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(s.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(s.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(s.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -190,12 +219,30 @@ func (s SubnetClassForm) BuildClassForm(className string, optionsResp interface{
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": s.deleteClass,
+			"onCancel":  s.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
 }
 
+func (s SubnetClassForm) togglePlanner(*gr.Event) {
+	s.SetState(gr.State{"showPlanner": !s.State().Bool("showPlanner")})
+}
+
+func (s SubnetClassForm) selectCidr(cidr string) {
+	s.SetState(gr.State{"cidr": cidr, "showPlanner": false})
+}
+
 func (s SubnetClassForm) backButton(*gr.Event) {
 	s.SetState(gr.State{"success": ""})
 	s.Props().Call("backButton")
@@ -233,7 +280,15 @@ func (s SubnetClassForm) saveButton(*gr.Event) {
 }
 
 func (s SubnetClassForm) deleteButton(*gr.Event) {
-	s.SetState(gr.State{"querying": true})
+	s.SetState(gr.State{"confirmDelete": true})
+}
+
+func (s SubnetClassForm) cancelDelete() {
+	s.SetState(gr.State{"confirmDelete": false})
+}
+
+func (s SubnetClassForm) deleteClass() {
+	s.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + s.Props().String("apiType") + "/name/" + s.Props().String("className")
@@ -0,0 +1,257 @@
+package forms
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+var (
+	userDataModes = []string{"Edit", "Highlight", "Preview"}
+
+	userDataYamlKey = regexp.MustCompile(`^(\s*-?\s*)([A-Za-z0-9_-]+:)(.*)$`)
+)
+
+// UserDataEditor edits instance user data with templates, validation, highlighting and a variable preview
+type UserDataEditor struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (u UserDataEditor) GetInitialState() gr.State {
+	return gr.State{"mode": "Edit", "volumeClasses": nil}
+}
+
+// Implements the ComponentWillMount interface
+func (u UserDataEditor) ComponentWillMount() {
+	// Get our volume classes for the mount volumes template
+	go func() {
+		endpoint := "//localhost:8081/api/classes/volumes"
+		resp, err := helpers.GetAPI(endpoint)
+		if !u.IsMounted() || err != nil {
+			return
+		}
+		u.SetState(gr.State{"volumeClasses": resp})
+	}()
+}
+
+func (u UserDataEditor) Render() gr.Component {
+
+	state := u.State()
+	props := u.Props()
+
+	userData := props.String("value")
+	vars := u.variables()
+	preview := userDataPreview(userData, vars)
+
+	editor := el.Div(gr.CSS("form-group", "user-data-editor"))
+
+	// Toolbar: modes and templates
+	modes := el.Div(gr.CSS("btn-group", "btn-group-sm"))
+	for _, mode := range userDataModes {
+		css := gr.CSS("btn", "btn-default")
+		if mode == state.String("mode") {
+			css = gr.CSS("btn", "btn-default", "active")
+		}
+		el.Button(evt.Click(u.setMode(mode)).PreventDefault(), css, gr.Text(mode)).Modify(modes)
+	}
+
+	templates := el.Div(gr.CSS("btn-group", "btn-group-sm", "pull-right"))
+	for _, template := range userDataTemplates {
+		el.Button(
+			evt.Click(u.applyTemplate(template)).PreventDefault(),
+			gr.CSS("btn", "btn-default"),
+			gr.Prop("title", template.Description),
+			gr.Text(template.Name),
+		).Modify(templates)
+	}
+
+	el.Div(
+		el.Label(gr.Text("User Data")),
+		el.Div(gr.CSS("btn-toolbar", "user-data-toolbar"), modes, templates),
+	).Modify(editor)
+
+	// Editor body
+	switch state.String("mode") {
+	case "Highlight":
+		highlightUserData(userData).Modify(editor)
+	case "Preview":
+		highlightUserData(preview).Modify(editor)
+	default:
+		el.TextArea(
+			attr.ClassName("form-control user-data-code"),
+			attr.Name("userData"),
+			attr.Placeholder("#!/bin/bash"),
+			attr.Value(userData),
+			attr.Rows(16),
+			gr.Prop("spellCheck", false),
+			evt.Change(u.storeValue),
+		).Modify(editor)
+	}
+
+	// Status, of the user data with its {{variables}} filled in
+	raw, encoded := helpers.UserDataSizes(preview)
+	sizeCSS := gr.CSS("label", "label-default")
+	if raw > helpers.UserDataLimit {
+		sizeCSS = gr.CSS("label", "label-danger")
+	}
+	el.Div(
+		gr.CSS("user-data-status"),
+		el.Span(gr.CSS("label", "label-info"), gr.Text(helpers.DetectUserDataType(preview))),
+		gr.Text(" "),
+		el.Span(sizeCSS, gr.Text(fmt.Sprintf("%d / %d bytes, %d base64 encoded", raw, helpers.UserDataLimit, encoded))),
+	).Modify(editor)
+
+	for _, problem := range helpers.ValidateUserData(preview) {
+		el.Div(
+			gr.CSS("invalid-message"),
+			el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+			gr.Text(" - "+problem),
+		).Modify(editor)
+	}
+
+	// Variables
+	if names := helpers.UserDataVariables(userData); len(names) > 0 {
+		varList := el.UnorderedList(gr.CSS("list-inline"))
+		for _, name := range names {
+			value, ok := vars[name]
+			label := el.Span(gr.CSS("label", "label-success"), gr.Text("{{"+name+"}} = "+value))
+			if !ok {
+				label = el.Span(gr.CSS("label", "label-warning"), gr.Text("{{"+name+"}} has no value"))
+			}
+			el.ListItem(label).Modify(varList)
+		}
+		varList.Modify(editor)
+	}
+
+	return editor
+}
+
+// userDataPreview fills in the {{variables}} that have a value, the rest are left as they are
+func userDataPreview(userData string, vars map[string]string) string {
+	values := make(map[string]string)
+	for key, value := range vars {
+		if value != "" {
+			values[key] = value
+		}
+	}
+	preview, _ := helpers.InterpolateUserData(userData, values)
+	return preview
+}
+
+// variables are the values available to {{variables}}, from the class being edited
+func (u UserDataEditor) variables() map[string]string {
+	vars := make(map[string]string)
+	if v, ok := u.Props().Interface("vars").(map[string]string); ok {
+		for key, value := range v {
+			if value != "" {
+				vars[key] = value
+			}
+		}
+	} else if v, ok := u.Props().Interface("vars").(map[string]interface{}); ok {
+		for key, value := range v {
+			if s, ok := value.(string); ok && s != "" {
+				vars[key] = s
+			}
+		}
+	}
+	return vars
+}
+
+// volumeMounts returns the device and mount point of each attached volume class that has both
+func (u UserDataEditor) volumeMounts() []volumeMount {
+	var mounts []volumeMount
+
+	resp, ok := u.State().Interface("volumeClasses").([]byte)
+	if !ok {
+		return mounts
+	}
+
+	jsonParsed, _ := gabs.ParseJSON(resp)
+	classes, _ := jsonParsed.S("classes").ChildrenMap()
+
+	names := stringList(u.Props().Interface("ebsVolumes"))
+	sort.Strings(names)
+	for _, name := range names {
+		class, ok := classes[name]
+		if !ok {
+			continue
+		}
+		deviceName, _ := class.S("deviceName").Data().(string)
+		mountPoint, _ := class.S("mountPoint").Data().(string)
+		if deviceName != "" && mountPoint != "" {
+			mounts = append(mounts, volumeMount{Class: name, DeviceName: deviceName, MountPoint: mountPoint})
+		}
+	}
+
+	return mounts
+}
+
+// highlightUserData renders user data with comments, shebangs, yaml keys and {{variables}} marked up
+func highlightUserData(userData string) *gr.Element {
+	code := gr.Elem("pre", gr.CSS("user-data-code", "user-data-highlight"))
+
+	for i, line := range strings.Split(userData, "\n") {
+		lineElem := el.Span(gr.CSS("user-data-line"))
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case i == 0 && (strings.HasPrefix(trimmed, "#!") || strings.HasPrefix(trimmed, "#cloud-config")):
+			el.Span(gr.CSS("ud-shebang"), gr.Text(line)).Modify(lineElem)
+		case strings.HasPrefix(trimmed, "#"):
+			el.Span(gr.CSS("ud-comment"), gr.Text(line)).Modify(lineElem)
+		case userDataYamlKey.MatchString(line):
+			match := userDataYamlKey.FindStringSubmatch(line)
+			gr.Text(match[1]).Modify(lineElem)
+			el.Span(gr.CSS("ud-key"), gr.Text(match[2])).Modify(lineElem)
+			highlightVariables(match[3]).Modify(lineElem)
+		default:
+			highlightVariables(line).Modify(lineElem)
+		}
+
+		gr.Text("\n").Modify(lineElem)
+		lineElem.Modify(code)
+	}
+
+	return code
+}
+
+func highlightVariables(text string) *gr.Element {
+	span := el.Span()
+	for {
+		start := strings.Index(text, "{{")
+		end := strings.Index(text, "}}")
+		if start < 0 || end < start {
+			gr.Text(text).Modify(span)
+			return span
+		}
+		gr.Text(text[:start]).Modify(span)
+		el.Span(gr.CSS("ud-var"), gr.Text(text[start:end+2])).Modify(span)
+		text = text[end+2:]
+	}
+}
+
+func (u UserDataEditor) setMode(mode string) func(*gr.Event) {
+	return func(*gr.Event) {
+		u.SetState(gr.State{"mode": mode})
+	}
+}
+
+func (u UserDataEditor) applyTemplate(template userDataTemplate) func(*gr.Event) {
+	return func(*gr.Event) {
+		u.Props().Call("onChange", template.Build(u.volumeMounts()))
+		u.SetState(gr.State{"mode": "Edit"})
+	}
+}
+
+func (u UserDataEditor) storeValue(event *gr.Event) {
+	u.Props().Call("onChange", event.TargetValue().String())
+}
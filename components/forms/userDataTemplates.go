@@ -0,0 +1,93 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// userDataTemplate is a starting point for instance user data
+type userDataTemplate struct {
+	Name        string
+	Description string
+	Build       func(mounts []volumeMount) string
+}
+
+// volumeMount is where a volume class attaches and mounts
+type volumeMount struct {
+	Class      string
+	DeviceName string
+	MountPoint string
+}
+
+var userDataTemplates = []userDataTemplate{
+	{
+		Name:        "Shell Script",
+		Description: "An empty bash script",
+		Build: func([]volumeMount) string {
+			return "#!/bin/bash\nset -euo pipefail\n\n"
+		},
+	},
+	{
+		Name:        "Bootstrap Agent",
+		Description: "Sets the hostname and installs the SSM and CloudWatch agents",
+		Build: func([]volumeMount) string {
+			return `#!/bin/bash
+set -euo pipefail
+
+INSTANCE_ID=$(curl -s http://169.254.169.254/latest/meta-data/instance-id)
+hostnamectl set-hostname "{{class}}-${INSTANCE_ID}"
+
+if command -v yum >/dev/null; then
+  yum install -y amazon-ssm-agent amazon-cloudwatch-agent
+else
+  snap install amazon-ssm-agent --classic
+  curl -sO https://s3.amazonaws.com/amazoncloudwatch-agent/ubuntu/amd64/latest/amazon-cloudwatch-agent.deb
+  dpkg -i amazon-cloudwatch-agent.deb
+fi
+
+systemctl enable --now amazon-ssm-agent || true
+`
+		},
+	},
+	{
+		Name:        "Mount Volumes",
+		Description: "Formats and mounts the EBS volume classes of this instance class at their mount points",
+		Build: func(mounts []volumeMount) string {
+			script := []string{"#!/bin/bash", "set -euo pipefail", ""}
+
+			if len(mounts) == 0 {
+				script = append(script, "# No EBS volume classes with a device name and mount point are attached to this class")
+			}
+
+			for _, mount := range mounts {
+				script = append(script,
+					fmt.Sprintf("# %s", mount.Class),
+					fmt.Sprintf("DEVICE=%s", mount.DeviceName),
+					"while [ ! -b \"$DEVICE\" ]; do sleep 1; done",
+					"if ! blkid \"$DEVICE\" >/dev/null; then mkfs -t ext4 \"$DEVICE\"; fi",
+					fmt.Sprintf("mkdir -p %s", mount.MountPoint),
+					fmt.Sprintf("grep -q \"^$DEVICE \" /etc/fstab || echo \"$DEVICE %s ext4 defaults,nofail 0 2\" >> /etc/fstab", mount.MountPoint),
+					fmt.Sprintf("mountpoint -q %s || mount %s", mount.MountPoint, mount.MountPoint),
+					"",
+				)
+			}
+
+			return strings.Join(script, "\n")
+		},
+	},
+	{
+		Name:        "Cloud Config",
+		Description: "A cloud-config document that updates packages and sets the hostname",
+		Build: func([]volumeMount) string {
+			return `#cloud-config
+hostname: "{{class}}"
+package_update: true
+package_upgrade: true
+packages:
+  - htop
+runcmd:
+  - echo "{{class}} is up" > /var/log/awsm-boot.log
+`
+		},
+	},
+}
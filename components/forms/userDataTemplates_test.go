@@ -0,0 +1,42 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+func TestUserDataTemplatesValidate(t *testing.T) {
+	mounts := []volumeMount{{Class: "data", DeviceName: "/dev/xvdf", MountPoint: "/data"}}
+	vars := map[string]string{"class": "web", "instanceType": "t3.micro", "vpc": "main", "subnet": "private", "keyName": "deploy"}
+
+	for _, template := range userDataTemplates {
+		userData := template.Build(mounts)
+
+		// As saved, and as it is previewed with and without values for its variables
+		for _, userData := range []string{userData, userDataPreview(userData, vars), userDataPreview(userData, nil)} {
+			if problems := helpers.ValidateUserData(userData); len(problems) > 0 {
+				t.Errorf("%s template: ValidateUserData() = %v, want no problems in:\n%s", template.Name, problems, userData)
+			}
+		}
+	}
+}
+
+func TestUserDataPreview(t *testing.T) {
+	tests := []struct {
+		userData string
+		vars     map[string]string
+		want     string
+	}{
+		{userData: "hostname {{class}}", vars: map[string]string{"class": "web"}, want: "hostname web"},
+		{userData: "subnet={{subnet}}", vars: map[string]string{"subnet": ""}, want: "subnet={{subnet}}"},
+		{userData: "key={{keyName}}", vars: nil, want: "key={{keyName}}"},
+		{userData: "#!/bin/bash\n", vars: map[string]string{"class": "web"}, want: "#!/bin/bash\n"},
+	}
+
+	for _, test := range tests {
+		if got := userDataPreview(test.userData, test.vars); got != test.want {
+			t.Errorf("userDataPreview(%q, %v) = %q, want %q", test.userData, test.vars, got, test.want)
+		}
+	}
+}
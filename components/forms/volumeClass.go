@@ -136,6 +136,10 @@ func (v VolumeClassForm) BuildClassForm(className string, optionsResp interface{
 	TextField("Attach Command", "attachCommand", state.String("attachCommand"), v.storeValue).Modify(classEditForm)
 	TextField("Detach Command", "detachCommand", state.String("detachCommand"), v.storeValue).Modify(classEditForm)
 
+	// Cost estimate
+	monthly, ok := helpers.VolumeMonthlyCost(state.String("volumeType"), state.Int("volumeSize"), state.Int("iops"), costRegion(state.String("costRegion")))
+	CostEstimate(monthly, ok, "Storage and provisioned IOPS, not including snapshots", state.String("costRegion"), v.storeSelect).Modify(classEditForm)
+
 	classEditForm.Modify(classEdit)
 
 	buttons := el.Div(
@@ -150,14 +154,16 @@ func (v VolumeClassForm) BuildClassForm(className string, optionsResp interface{
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(v.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(v.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(v.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -165,7 +171,17 @@ func (v VolumeClassForm) BuildClassForm(className string, optionsResp interface{
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": v.deleteClass,
+			"onCancel":  v.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -188,6 +204,7 @@ func (v VolumeClassForm) saveButton(*gr.Event) {
 	for key, _ := range v.State() {
 		cfg[key] = v.State().Interface(key)
 	}
+	delete(cfg, "costRegion")
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + v.Props().String("apiType") + "/name/" + v.Props().String("className")
@@ -208,7 +225,15 @@ func (v VolumeClassForm) saveButton(*gr.Event) {
 }
 
 func (v VolumeClassForm) deleteButton(*gr.Event) {
-	v.SetState(gr.State{"querying": true})
+	v.SetState(gr.State{"confirmDelete": true})
+}
+
+func (v VolumeClassForm) cancelDelete() {
+	v.SetState(gr.State{"confirmDelete": false})
+}
+
+func (v VolumeClassForm) deleteClass() {
+	v.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + v.Props().String("apiType") + "/name/" + v.Props().String("className")
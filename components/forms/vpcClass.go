@@ -123,6 +123,18 @@ func (v VpcClassForm) BuildClassForm(className string, optionsResp interface{})
 	classEditForm := el.Form(evt.KeyDown(DisableEnter))
 
 	TextField("CIDR", "cidr", state.String("cidr"), v.storeValue).Modify(classEditForm)
+
+	if cidr := state.String("cidr"); cidr != "" {
+		if _, err := helpers.ParseCidrBlock(cidr); err != nil {
+			el.Div(
+				gr.CSS("invalid-message"),
+				el.Italic(gr.CSS("fa", "fa-exclamation-circle")),
+				gr.Text(" - "+err.Error()),
+			).Modify(classEditForm)
+		} else {
+			gr.New(&CidrPlanner{}).CreateElement(gr.Props{"vpcCidr": cidr}).Modify(classEditForm)
+		}
+	}
 	SelectOne("Tenancy", "tenancy", tenancy, state.Interface("tenancy"), v.storeSelect).Modify(classEditForm)
 
 	classEditForm.Modify(classEdit)
@@ -139,14 +151,16 @@ func (v VpcClassForm) BuildClassForm(className string, optionsResp interface{})
 	).Modify(buttons)
 
 	// Save
-	el.Button(
-		evt.Click(v.saveButton).PreventDefault(),
-		gr.CSS("btn", "btn-primary"),
-		gr.Text("Save"),
-	).Modify(buttons)
+	if helpers.CanEdit() {
+		el.Button(
+			evt.Click(v.saveButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Save"),
+		).Modify(buttons)
+	}
 
 	// Delete
-	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") {
+	if props.Interface("hasDelete") != nil && props.Bool("hasDelete") && helpers.CanEdit() {
 		el.Button(
 			evt.Click(v.deleteButton).PreventDefault(),
 			gr.CSS("btn", "btn-danger", "pull-right"),
@@ -154,7 +168,17 @@ func (v VpcClassForm) BuildClassForm(className string, optionsResp interface{})
 		).Modify(buttons)
 	}
 
-	buttons.Modify(classEdit)
+	// Delete confirmation
+	if state.Bool("confirmDelete") {
+		gr.New(&DeleteClassConfirm{}).CreateElement(gr.Props{
+			"apiType":   props.String("apiType"),
+			"className": props.String("className"),
+			"onConfirm": v.deleteClass,
+			"onCancel":  v.cancelDelete,
+		}).Modify(classEdit)
+	} else {
+		buttons.Modify(classEdit)
+	}
 
 	return classEdit
 
@@ -197,7 +221,15 @@ func (v VpcClassForm) saveButton(*gr.Event) {
 }
 
 func (v VpcClassForm) deleteButton(*gr.Event) {
-	v.SetState(gr.State{"querying": true})
+	v.SetState(gr.State{"confirmDelete": true})
+}
+
+func (v VpcClassForm) cancelDelete() {
+	v.SetState(gr.State{"confirmDelete": false})
+}
+
+func (v VpcClassForm) deleteClass() {
+	v.SetState(gr.State{"querying": true, "confirmDelete": false})
 
 	go func() {
 		endpoint := "//localhost:8081/api/classes/" + v.Props().String("apiType") + "/name/" + v.Props().String("className")
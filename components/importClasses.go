@@ -0,0 +1,272 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+type ImportClasses struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (i ImportClasses) GetInitialState() gr.State {
+	return gr.State{"step": 1, "querying": false, "error": "", "success": "", "fileName": "", "bundle": nil, "existing": []string{}, "decisions": nil, "results": []string{}}
+}
+
+func (i ImportClasses) Render() gr.Component {
+
+	state := i.State()
+
+	// Response placeholder
+	response := el.Div()
+
+	// Print any alerts
+	helpers.ErrorElem(state.String("error")).Modify(response)
+	helpers.SuccessElem(state.String("success")).Modify(response)
+
+	buttons := el.Div(
+		gr.CSS("btn-toolbar"),
+	)
+
+	if state.Int("step") == 1 {
+
+		// STEP 1
+
+		if state.Bool("querying") {
+			gr.Text("Reading " + state.String("fileName") + "...").Modify(response)
+			return response
+		}
+
+		el.Div(
+			gr.CSS("form-group"),
+			el.Label(gr.Text("Class Bundle (yaml or json)")),
+			el.Input(
+				attr.Type("file"),
+				attr.ClassName("form-control"),
+				attr.Accept(".yaml,.yml,.json"),
+				evt.Change(i.selectFile),
+			),
+		).Modify(response)
+
+		// Close
+		el.Button(
+			evt.Click(i.closeButton).PreventDefault(),
+			gr.CSS("btn", "btn-secondary"),
+			gr.Text("Close"),
+		).Modify(buttons)
+
+	} else if state.Int("step") == 2 {
+
+		// STEP 2
+
+		if state.Bool("querying") {
+			gr.Text("Importing...").Modify(response)
+			return response
+		}
+
+		var bundle ClassBundle
+		json.Unmarshal(state.Interface("bundle").([]byte), &bundle)
+		existing := stringSet(state.Interface("existing"))
+		decisions, _ := state.Interface("decisions").(map[string]interface{})
+
+		el.Paragraph(gr.Text(state.String("fileName"))).Modify(response)
+
+		tBody := el.TableBody()
+		for _, entry := range bundle.Entries() {
+			status := "new"
+			options := []string{"create", "skip"}
+			if existing[entry.Key()] {
+				status = "exists"
+				options = []string{"overwrite", "skip"}
+			}
+
+			el.TableRow(
+				el.TableData(gr.Text(entry.Type)),
+				el.TableData(gr.Text(entry.Name)),
+				el.TableData(gr.Text(status)),
+				el.TableData(forms.SelectOne("", entry.Key(), options, decisions[entry.Key()], i.storeDecision)),
+			).Modify(tBody)
+		}
+
+		el.Table(
+			gr.CSS("table", "table-striped"),
+			gr.Style("width", "100%"),
+			el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Type", "Name", "Status", "Action"})...)),
+			tBody,
+		).Modify(response)
+
+		// Back
+		el.Button(
+			evt.Click(i.stepTwoBack).PreventDefault(),
+			gr.CSS("btn", "btn-secondary"),
+			gr.Text("Back"),
+		).Modify(buttons)
+
+		// Import
+		el.Button(
+			evt.Click(i.importButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Import"),
+		).Modify(buttons)
+
+	} else if state.Int("step") == 3 {
+
+		// STEP 3
+
+		results := el.UnorderedList()
+		if list, ok := state.Interface("results").([]interface{}); ok {
+			for _, result := range list {
+				el.ListItem(gr.Text(fmt.Sprint(result))).Modify(results)
+			}
+		}
+		results.Modify(response)
+
+		// Done
+		el.Button(
+			evt.Click(i.closeButton).PreventDefault(),
+			gr.CSS("btn", "btn-primary"),
+			gr.Text("Done"),
+		).Modify(buttons)
+	}
+
+	buttons.Modify(response)
+
+	return response
+}
+
+func (i ImportClasses) selectFile(event *gr.Event) {
+	file := event.Target().Get("files").Index(0)
+	fileName := file.Get("name").String()
+
+	i.SetState(gr.State{"querying": true, "fileName": fileName, "error": "", "success": ""})
+
+	go func() {
+		data, err := helpers.ReadFile(file)
+		if !i.IsMounted() {
+			return
+		}
+		if err != nil {
+			i.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		bundle, err := DecodeClassBundle(data)
+		if err != nil {
+			i.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		// Find out which of these classes already exist
+		var classTypes []string
+		for classType := range bundle.Classes {
+			classTypes = append(classTypes, classType)
+		}
+		current, err := fetchClassBundle(classTypes)
+		if !i.IsMounted() {
+			return
+		}
+		if err != nil {
+			i.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		var existing []string
+		decisions := make(map[string]interface{})
+		for _, entry := range bundle.Entries() {
+			if _, ok := current.Classes[entry.Type][entry.Name]; ok {
+				existing = append(existing, entry.Key())
+				decisions[entry.Key()] = "skip"
+			} else {
+				decisions[entry.Key()] = "create"
+			}
+		}
+
+		bundleJson, _ := json.Marshal(bundle)
+		i.SetState(gr.State{"querying": false, "step": 2, "bundle": bundleJson, "existing": existing, "decisions": decisions})
+	}()
+}
+
+func (i ImportClasses) storeDecision(key string, val interface{}) {
+	decisions, ok := i.State().Interface("decisions").(map[string]interface{})
+	if !ok {
+		decisions = make(map[string]interface{})
+	}
+
+	if value, ok := val.(map[string]interface{}); ok {
+		decisions[key] = value["value"]
+	} else {
+		decisions[key] = "skip"
+	}
+
+	i.SetState(gr.State{"decisions": decisions})
+}
+
+func (i ImportClasses) importButton(*gr.Event) {
+	if !helpers.CanEdit() {
+		i.SetState(gr.State{"error": helpers.ErrReadOnly.Error()})
+		return
+	}
+
+	state := i.State()
+
+	var bundle ClassBundle
+	json.Unmarshal(state.Interface("bundle").([]byte), &bundle)
+	decisions, _ := state.Interface("decisions").(map[string]interface{})
+
+	i.SetState(gr.State{"querying": true, "error": ""})
+
+	go func() {
+		var results []string
+		failed := 0
+
+		for _, entry := range bundle.Entries() {
+			decision, _ := decisions[entry.Key()].(string)
+			if decision != "create" && decision != "overwrite" {
+				results = append(results, entry.Key()+": skipped")
+				continue
+			}
+
+			endpoint := "//localhost:8081/api/classes/" + entry.Type + "/name/" + entry.Name
+			_, err := helpers.PutAPI(endpoint, bundle.Classes[entry.Type][entry.Name])
+			if err != nil {
+				failed++
+				results = append(results, fmt.Sprintf("%s: error while querying endpoint: %s", entry.Key(), endpoint))
+				continue
+			}
+
+			if decision == "create" {
+				results = append(results, entry.Key()+": created")
+			} else {
+				results = append(results, entry.Key()+": overwritten")
+			}
+		}
+
+		if !i.IsMounted() {
+			return
+		}
+
+		if failed > 0 {
+			i.SetState(gr.State{"querying": false, "step": 3, "results": results, "error": fmt.Sprintf("%d classes failed to import", failed)})
+			return
+		}
+
+		i.SetState(gr.State{"querying": false, "step": 3, "results": results, "success": "Classes were imported"})
+	}()
+}
+
+func (i ImportClasses) stepTwoBack(*gr.Event) {
+	i.SetState(gr.State{"step": 1, "error": "", "bundle": nil, "decisions": nil})
+}
+
+func (i ImportClasses) closeButton(*gr.Event) {
+	i.SetState(gr.State{"step": 1, "success": "", "error": "", "bundle": nil, "decisions": nil})
+	hideAllModals()
+}
@@ -1,8 +1,11 @@
 package components
 
 import (
+	"strings"
+
 	"github.com/bep/gr"
 	"github.com/bep/gr/el"
+	"github.com/murdinc/awsmDashboard/helpers"
 )
 
 type Layout struct {
@@ -18,10 +21,19 @@ type Page struct {
 	Route      string
 	ApiType    string
 	Type       string
+	Icon       string // font awesome icon, "fa-server"
+	Group      string // nav group, one of NavGroups, or empty to list the page above the groups
+	Order      int    // position in the nav, within its group
 	HasClasses bool
+	HasAssets  bool
 	HasWidgets bool
 }
 
+// Linkable returns false for pages with route parameters, like "/vpcs/:id", which are reached from other pages
+func (p Page) Linkable() bool {
+	return !strings.Contains(p.Route, ":")
+}
+
 // Implements the Renderer interface.
 func (l Layout) Render() gr.Component {
 
@@ -31,6 +43,10 @@ func (l Layout) Render() gr.Component {
 		gr.New(&Nav{Brand: l.Brand, Pages: l.Pages}).CreateElement(l.Props()), // layout passes the router to the nav
 
 		//Content
-		gr.New(&Content{Page: l.Pages[l.ActivePage]}).CreateElement(gr.Props{"activePage": l.ActivePage}),
+		// keyed by the url so links that only change the query string, like the command palette's, remount the page
+		gr.New(&Content{Page: l.Pages[l.ActivePage]}).CreateElement(gr.Props{"activePage": l.ActivePage, "key": helpers.CurrentURL()}),
+
+		// Command Palette
+		gr.New(&CommandPalette{Pages: l.Pages}).CreateElement(gr.Props{}),
 	)
 }
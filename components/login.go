@@ -0,0 +1,182 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/attr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// Login signs users in with an awsm API token or the OIDC provider, and out again
+type Login struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (l Login) GetInitialState() gr.State {
+	return gr.State{"token": "", "querying": false, "error": ""}
+}
+
+// Implements the ComponentWillMount interface
+func (l Login) ComponentWillMount() {
+	// Finish signing in if the OIDC provider redirected back here
+	idToken, err := helpers.OidcCallback()
+	if err != nil {
+		l.SetState(gr.State{"error": err.Error()})
+		return
+	}
+	if idToken == "" {
+		return
+	}
+
+	l.SetState(gr.State{"querying": true})
+	go func() {
+		_, err := helpers.SignInWithIDToken(idToken)
+		if !l.IsMounted() {
+			return
+		}
+		if err != nil {
+			l.SetState(gr.State{"querying": false, "error": "Error while signing in: " + err.Error()})
+			return
+		}
+		signedIn()
+	}()
+}
+
+func (l Login) Render() gr.Component {
+
+	state := l.State()
+
+	response := el.Div(gr.CSS("login"))
+
+	if state.Bool("querying") {
+		gr.Text("Signing in...").Modify(response)
+		return response
+	}
+
+	if errStr := state.String("error"); errStr != "" {
+		helpers.ErrorElem(errStr).Modify(response)
+	}
+
+	// Signed in
+	if session := helpers.CurrentSession(); session != nil {
+		role := "read-only"
+		if session.CanEdit() {
+			role = "can edit"
+		}
+
+		el.Paragraph(
+			gr.Text("Signed in as "),
+			el.Strong(gr.Text(session.User)),
+			gr.Text(" ("+strings.Join(session.Roles, ", ")+", "+role+")"),
+		).Modify(response)
+
+		el.Div(
+			gr.CSS("btn-toolbar"),
+			el.Button(
+				evt.Click(l.signOutButton).PreventDefault(),
+				gr.CSS("btn", "btn-default"),
+				gr.Text("Sign Out"),
+			),
+		).Modify(response)
+
+		return response
+	}
+
+	// OIDC
+	el.Div(
+		gr.CSS("panel", "panel-default"),
+		el.Div(gr.CSS("panel-heading"), el.Header4(gr.Text("Single Sign-On"))),
+		el.Div(
+			gr.CSS("panel-body"),
+			el.Paragraph(gr.Text("Sign in with your organization account, your roles come from your groups.")),
+			el.Button(
+				evt.Click(l.oidcButton).PreventDefault(),
+				gr.CSS("btn", "btn-primary"),
+				el.Italic(gr.CSS("fa", "fa-sign-in")),
+				gr.Text(" Sign In With SSO"),
+			),
+		),
+	).Modify(response)
+
+	// API Token
+	el.Div(
+		gr.CSS("panel", "panel-default"),
+		el.Div(gr.CSS("panel-heading"), el.Header4(gr.Text("API Token"))),
+		el.Div(
+			gr.CSS("panel-body"),
+			el.Form(
+				evt.KeyDown(forms.CaptureEnter(l.tokenButton)),
+				el.Div(
+					gr.CSS("form-group"),
+					el.Label(gr.Text("Token")),
+					el.Input(
+						attr.Type("password"),
+						attr.ClassName("form-control"),
+						attr.Name("token"),
+						attr.Placeholder("Token"),
+						attr.Value(state.String("token")),
+						evt.Change(l.storeValue),
+					),
+				),
+			),
+			el.Button(
+				evt.Click(l.tokenButton).PreventDefault(),
+				gr.CSS("btn", "btn-primary"),
+				gr.Text("Sign In"),
+			),
+		),
+	).Modify(response)
+
+	return response
+}
+
+func (l Login) tokenButton(*gr.Event) {
+	token := strings.TrimSpace(l.State().String("token"))
+	if token == "" {
+		l.SetState(gr.State{"error": "Enter an API token to sign in!"})
+		return
+	}
+
+	l.SetState(gr.State{"querying": true, "error": ""})
+	go func() {
+		_, err := helpers.SignInWithToken(token)
+		if !l.IsMounted() {
+			return
+		}
+		if err != nil {
+			l.SetState(gr.State{"querying": false, "error": "Error while signing in: " + err.Error()})
+			return
+		}
+		signedIn()
+	}()
+}
+
+func (l Login) oidcButton(*gr.Event) {
+	loginURL, err := helpers.OidcLoginURL()
+	if err != nil {
+		l.SetState(gr.State{"error": err.Error()})
+		return
+	}
+	js.Global.Get("location").Set("href", loginURL)
+}
+
+func (l Login) signOutButton(*gr.Event) {
+	helpers.ClearSession()
+	signedIn()
+}
+
+func (l Login) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	l.SetState(gr.State{key: event.TargetValue().String()})
+}
+
+// signedIn reloads the dashboard so every page renders for the new role
+func signedIn() {
+	js.Global.Get("location").Set("href", "/")
+}
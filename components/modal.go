@@ -5,6 +5,7 @@ import (
 	"github.com/bep/gr/attr"
 	"github.com/bep/gr/el"
 	"github.com/gopherjs/jquery"
+	"github.com/murdinc/awsmDashboard/helpers"
 )
 
 var jQuery = jquery.NewJQuery
@@ -21,6 +22,18 @@ func hideModal(id string) {
 	jQuery("#"+id).Call("modal", "hide")
 }
 
+// showRequestedModal opens the modal named in the url, "?modal=edit-class" opens #edit-class-modal, if it is one of
+// the modals given
+func showRequestedModal(modals ...string) {
+	requested := helpers.QueryParam("modal")
+	for _, modal := range modals {
+		if modal == requested {
+			jQuery("#"+modal+"-modal").Call("modal", "show")
+			return
+		}
+	}
+}
+
 func (m Modal) onShow(event *gr.Event) {
 	//println("onShow")
 }
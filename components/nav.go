@@ -1,28 +1,128 @@
 package components
 
 import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Jeffail/gabs"
 	"github.com/bep/gr"
 	"github.com/bep/gr/attr"
 	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
 	"github.com/bep/grouter"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// NavGroups are the nav sections, in order. Pages without a group are listed above them
+var NavGroups = []string{"Compute", "Storage", "Network", "Monitoring", "Scaling"}
+
+// Every page renders its own Nav, so asset counts are kept here and only refreshed once they are a minute old
+var (
+	navCounts   = make(map[string]int)
+	navCountsAt time.Time
 )
 
+const navCountsMaxAge = time.Minute
+
 type Nav struct {
 	*gr.This
 	Pages
 	Brand string
 }
 
+// Implements the StateInitializer interface
+func (c Nav) GetInitialState() gr.State {
+	return gr.State{"collapsed": helpers.LoadCollapsedNavGroups(), "counted": 0}
+}
+
+// Implements the ComponentDidMount interface
+func (c Nav) ComponentDidMount() {
+	if time.Since(navCountsAt) > navCountsMaxAge {
+		c.getCounts()
+	}
+}
+
+// getCounts counts the assets of every asset page
+func (c Nav) getCounts() {
+	navCountsAt = time.Now()
+
+	for _, page := range c.Pages {
+		if !page.HasAssets {
+			continue
+		}
+
+		go func(apiType string) {
+			endpoint := "//localhost:8081/api/assets/" + apiType
+			resp, err := helpers.GetAPI(endpoint)
+			if err != nil {
+				println("Error while querying endpoint: " + endpoint)
+				return
+			}
+
+			jsonParsed, _ := gabs.ParseJSON(resp)
+			assets, _ := jsonParsed.S("assets").Children()
+			navCounts[apiType] = len(assets)
+
+			if c.IsMounted() {
+				c.SetState(gr.State{"counted": len(navCounts)})
+			}
+		}(page.ApiType)
+	}
+}
+
 // Implements the Renderer interface.
 func (c Nav) Render() gr.Component {
 
+	collapsed := stringSliceState(c.State().Interface("collapsed"))
+
+	// Pages by group, in order
+	grouped := make(map[string]navPages)
+	for name, page := range c.Pages {
+		if page.Route != "/" && page.Route != "/login" && page.Linkable() {
+			grouped[page.Group] = append(grouped[page.Group], navPage{Name: name, Page: page})
+		}
+	}
+
 	links := el.UnorderedList(
 		gr.CSS("nav-menu", "nav-pills", "nav-stacked"),
 	)
 
-	for name, page := range c.Pages {
-		if page.Route != "/" {
-			c.createLinkListItem(page.Route, name).Modify(links)
+	sort.Sort(grouped[""])
+	for _, p := range grouped[""] {
+		c.createLinkListItem(p).Modify(links)
+	}
+
+	for _, group := range NavGroups {
+		pages := grouped[group]
+		if len(pages) == 0 {
+			continue
+		}
+		sort.Sort(pages)
+
+		isCollapsed := containsString(collapsed, group)
+		caret := "fa-caret-down"
+		if isCollapsed {
+			caret = "fa-caret-right"
+		}
+
+		header := el.ListItem(
+			gr.CSS("nav-group"),
+			attr.Key("group-"+group),
+			evt.Click(c.toggleGroup(group)),
+			el.Italic(gr.CSS("fa", "fa-fw", caret)),
+			gr.Text(" "+group),
+		)
+		if total, ok := pages.count(); ok && isCollapsed {
+			navBadge(total).Modify(header)
+		}
+		header.Modify(links)
+
+		if isCollapsed {
+			continue
+		}
+		for _, p := range pages {
+			c.createLinkListItem(p).Modify(links)
 		}
 	}
 
@@ -41,13 +141,84 @@ func (c Nav) Render() gr.Component {
 		links,
 	)
 
+	// Signed in user
+	account := "Sign In"
+	if session := helpers.CurrentSession(); session != nil {
+		account = session.User
+	}
+	el.Div(
+		gr.CSS("nav-user"),
+		el.Italic(gr.CSS("fa", "fa-user")),
+		gr.Text(" "),
+		grouter.Link("/login", account),
+	).Modify(elem)
+
 	return elem
 }
 
-func (c Nav) createLinkListItem(path, title string) gr.Modifier {
-	return el.ListItem(
-		grouter.MarkIfActive(c.Props(), path),
-		grouter.Link(path, title),
-		attr.Key(title), // not handling the warning, apparently.
+func (c Nav) createLinkListItem(p navPage) gr.Modifier {
+	item := el.ListItem(
+		grouter.MarkIfActive(c.Props(), p.Route),
+		attr.Key(p.Name), // not handling the warning, apparently.
 	)
+	if p.Icon != "" {
+		el.Italic(gr.CSS("fa", "fa-fw", p.Icon)).Modify(item)
+	}
+	if count, ok := navCounts[p.ApiType]; ok && p.HasAssets {
+		navBadge(count).Modify(item)
+	}
+	grouter.Link(p.Route, p.Name).Modify(item)
+
+	return item
+}
+
+func (c Nav) toggleGroup(group string) func(*gr.Event) {
+	return func(*gr.Event) {
+		var collapsed []string
+		for _, g := range stringSliceState(c.State().Interface("collapsed")) {
+			if g != group {
+				collapsed = append(collapsed, g)
+			}
+		}
+		if !containsString(stringSliceState(c.State().Interface("collapsed")), group) {
+			collapsed = append(collapsed, group)
+		}
+
+		helpers.SaveCollapsedNavGroups(collapsed)
+		c.SetState(gr.State{"collapsed": collapsed})
+	}
+}
+
+func navBadge(count int) *gr.Element {
+	return el.Span(gr.CSS("badge", "nav-count"), gr.Text(strconv.Itoa(count)))
+}
+
+type navPage struct {
+	Name string
+	Page
+}
+
+// navPages sorts by order, then name
+type navPages []navPage
+
+func (n navPages) Len() int      { return len(n) }
+func (n navPages) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+func (n navPages) Less(i, j int) bool {
+	if n[i].Order != n[j].Order {
+		return n[i].Order < n[j].Order
+	}
+	return n[i].Name < n[j].Name
+}
+
+// count totals the assets of the pages, if any have been counted
+func (n navPages) count() (int, bool) {
+	var total int
+	var counted bool
+	for _, p := range n {
+		if count, ok := navCounts[p.ApiType]; ok && p.HasAssets {
+			total += count
+			counted = true
+		}
+	}
+	return total, counted
 }
@@ -97,49 +97,57 @@ func (n NewClass) closeButton(*gr.Event) {
 }
 
 func (n NewClass) stepOneNext(e *gr.Event) {
+	if !helpers.CanEdit() {
+		n.SetState(gr.State{"error": helpers.ErrReadOnly.Error()})
+		return
+	}
 
 	go func(className string) {
 
-		// Make sure the classname isn't empty
-		if className == "" {
-			n.SetState(gr.State{"error": "Class name cannot be empty", "querying": false})
+		if errStr := checkNewClassName(n.Props().String("apiType"), className); errStr != "" {
+			n.SetState(gr.State{"error": errStr, "querying": false})
 			return
 		}
 
-		// Make sure the classname doesn't include any numbers
-		for _, char := range className {
-			if char >= '0' && char <= '9' {
-				n.SetState(gr.State{"error": "Class name cannot contain numbers", "querying": false})
-				return
-			}
-		}
-
-		// Make sure this class name doesn't already exist
-		if apiType := n.Props().String("apiType"); apiType != "" {
-			endpoint := "//localhost:8081/api/classes/" + apiType + "/name/" + className
-			resp, err := helpers.GetAPI(endpoint)
-
-			if err != nil {
-				n.SetState(gr.State{"error": fmt.Sprintf("Error while querying endpoint: %s", endpoint), "querying": false})
-				return
-			}
-
-			jsonParsed, _ := gabs.ParseJSON(resp)
-			exists := jsonParsed.S("success").Data().(bool)
-
-			if exists {
-				n.SetState(gr.State{"error": "Class name " + className + " already exists!", "querying": false})
-				return
-			}
-		} else {
-			n.SetState(gr.State{"error": "No API type, unable to query API", "querying": false})
-			return
-		}
 		n.SetState(gr.State{"error": "", "querying": false, "step": 2})
 	}(n.State().String("className"))
 
 }
 
+// checkNewClassName returns an error string if className is not usable as a new class of apiType
+func checkNewClassName(apiType, className string) string {
+
+	// Make sure the classname isn't empty
+	if className == "" {
+		return "Class name cannot be empty"
+	}
+
+	// Make sure the classname doesn't include any numbers
+	for _, char := range className {
+		if char >= '0' && char <= '9' {
+			return "Class name cannot contain numbers"
+		}
+	}
+
+	// Make sure this class name doesn't already exist
+	if apiType == "" {
+		return "No API type, unable to query API"
+	}
+
+	endpoint := "//localhost:8081/api/classes/" + apiType + "/name/" + className
+	resp, err := helpers.GetAPI(endpoint)
+	if err != nil {
+		return fmt.Sprintf("Error while querying endpoint: %s", endpoint)
+	}
+
+	jsonParsed, _ := gabs.ParseJSON(resp)
+	if exists, _ := jsonParsed.S("success").Data().(bool); exists {
+		return "Class name " + className + " already exists!"
+	}
+
+	return ""
+}
+
 func (n NewClass) stepTwoBack(event *gr.Event) {
 	n.SetState(gr.State{"step": 1})
 }
@@ -0,0 +1,80 @@
+package components
+
+// AppPages returns every page the dashboard serves, the pages below plus one for each registered asset type
+func AppPages() Pages {
+	pages := Pages{
+		"Dashboard": Page{
+			Route:      "/",
+			ApiType:    "dashboard",
+			Type:       "Dashboard",
+			Icon:       "fa-tachometer",
+			HasWidgets: true,
+		},
+		"Class Graph": Page{
+			Route:   "/classgraph",
+			ApiType: "classgraph",
+			Type:    "Class Graph",
+			Icon:    "fa-share-alt",
+			Order:   10,
+		},
+		"Exposure Report": Page{
+			Route:   "/exposure",
+			ApiType: "exposure",
+			Type:    "Exposure Report",
+			Icon:    "fa-exclamation-triangle",
+			Group:   "Network",
+			Order:   1000,
+		},
+		"Scaling Simulator": Page{
+			Route:   "/scalingsimulator",
+			ApiType: "scalingsimulator",
+			Type:    "Scaling Simulator",
+			Icon:    "fa-sliders",
+			Group:   "Scaling",
+			Order:   1000,
+		},
+		"Rollouts": Page{
+			Route:   "/rollouts",
+			ApiType: "rollouts",
+			Type:    "Rollouts",
+			Icon:    "fa-refresh",
+			Group:   "Scaling",
+			Order:   1010,
+		},
+		"Retention": Page{
+			Route:   "/retention",
+			ApiType: "retention",
+			Type:    "Retention",
+			Icon:    "fa-history",
+			Group:   "Storage",
+			Order:   1000,
+		},
+		"Audit Log": Page{
+			Route:   "/audit",
+			ApiType: "audit",
+			Type:    "Audit Log",
+			Icon:    "fa-list-alt",
+			Group:   "Monitoring",
+			Order:   1000,
+		},
+		"VPC Topology": Page{
+			Route:   "/vpcs/:id",
+			ApiType: "vpctopology",
+			Type:    "VPC Topology",
+			Icon:    "fa-object-group",
+			Group:   "Network",
+		},
+		"Sign In": Page{
+			Route:   "/login",
+			ApiType: "login",
+			Type:    "Sign In",
+			Icon:    "fa-user",
+		},
+	}
+
+	for name, page := range AssetTypePages() {
+		pages[name] = page
+	}
+
+	return pages
+}
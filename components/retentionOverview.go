@@ -0,0 +1,296 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+var (
+	// retentionTypes are the class types that rotate versioned assets
+	retentionTypes = []string{"snapshots", "images"}
+
+	// retentionIDKeys and retentionCreatedKeys are where each asset type keeps its id and creation time
+	retentionIDKeys = map[string][]string{
+		"snapshots": {"snapshotID"},
+		"images":    {"imageID"},
+	}
+
+	retentionCreatedKeys = map[string][]string{
+		"snapshots": {"startTime", "creationTime"},
+		"images":    {"creationDate", "creationTime"},
+	}
+)
+
+type RetentionOverview struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (r RetentionOverview) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "success": "", "bundle": nil, "snapshots": nil, "images": nil, "rotating": ""}
+}
+
+// Implements the ComponentWillMount interface
+func (r RetentionOverview) ComponentWillMount() {
+	r.SetState(gr.State{"querying": true})
+	go r.refresh()
+}
+
+// refresh reloads the classes and live assets
+func (r RetentionOverview) refresh() {
+	bundle, err := fetchClassBundle(retentionTypes)
+	if !r.IsMounted() {
+		return
+	}
+	if err != nil {
+		r.SetState(gr.State{"querying": false, "error": err.Error()})
+		return
+	}
+
+	state := gr.State{"querying": false}
+	for _, apiType := range retentionTypes {
+		endpoint := "//localhost:8081/api/assets/" + apiType
+		resp, err := helpers.GetAPI(endpoint)
+		if !r.IsMounted() {
+			return
+		}
+		if err != nil {
+			r.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+			return
+		}
+		state[apiType] = resp
+	}
+
+	bundleJson, _ := json.Marshal(bundle)
+	state["bundle"] = bundleJson
+	r.SetState(state)
+}
+
+func (r RetentionOverview) Render() gr.Component {
+
+	state := r.State()
+
+	// Overview placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+	helpers.SuccessElem(state.String("success")).Modify(response)
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return elem
+	}
+
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	for _, classType := range retentionTypes {
+		assets := r.rotationAssets(classType)
+
+		var classNames []string
+		for name := range bundle.Classes[classType] {
+			classNames = append(classNames, name)
+		}
+		sort.Strings(classNames)
+
+		el.Header3(gr.Text(strings.Title(classType))).Modify(response)
+		if len(classNames) == 0 {
+			gr.Text("Nothing here!").Modify(response)
+			continue
+		}
+
+		for _, className := range classNames {
+			r.buildClassPanel(classType, className, bundle.Classes[classType][className], assets[className]).Modify(response)
+		}
+	}
+
+	return elem
+}
+
+func (r RetentionOverview) buildClassPanel(classType, className string, class map[string]interface{}, assets []helpers.RotationAsset) *gr.Element {
+	classVersion := int(classNumber(class["version"]))
+	retain := int(classNumber(class["retain"]))
+	rotate, _ := class["rotate"].(bool)
+	propagate, _ := class["propagate"].(bool)
+
+	body := el.Div(gr.CSS("panel-body"))
+
+	summary := fmt.Sprintf("Class version %d. ", classVersion)
+	if rotate {
+		summary += fmt.Sprintf("Rotating, retaining %d per region.", retain)
+	} else {
+		summary += "Rotation disabled, nothing will be pruned."
+		retain = 0
+	}
+	el.Paragraph(gr.Text(summary)).Modify(body)
+
+	// Versions by region, with what rotation would prune
+	tBody := el.TableBody()
+	for _, plan := range helpers.PlanRotation(assets, retain) {
+		for _, asset := range plan.Keep {
+			status := "keep"
+			if plan.NextPrune != nil && plan.NextPrune.ID == asset.ID {
+				status = "pruned next"
+			}
+			retentionRow(plan.Region, asset, status).Modify(tBody)
+		}
+		for _, asset := range plan.Prune {
+			row := retentionRow(plan.Region, asset, "pruned on next rotation")
+			gr.CSS("danger").Modify(row)
+			row.Modify(tBody)
+		}
+	}
+	if len(assets) == 0 {
+		el.TableRow(el.TableData(gr.Text("No " + classType + " have been created from this class yet"))).Modify(tBody)
+	}
+
+	el.Table(
+		gr.CSS("table", "table-condensed"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Region", "Version", "Name", "ID", "Created", "Retention"})...)),
+		tBody,
+	).Modify(body)
+
+	// Propagation
+	if propagate {
+		latest := helpers.LatestVersions(assets)
+		newest := 0
+		for _, version := range latest {
+			if version > newest {
+				newest = version
+			}
+		}
+
+		propagation := el.UnorderedList(gr.CSS("list-inline"))
+		for _, region := range helpers.ReferencedNames(class, "propagateRegions") {
+			label := el.Span(gr.CSS("label", "label-success"), gr.Text(region+" v"+strconv.Itoa(latest[region])))
+			switch {
+			case latest[region] == 0:
+				label = el.Span(gr.CSS("label", "label-danger"), gr.Text(region+" missing"))
+			case latest[region] < newest:
+				label = el.Span(gr.CSS("label", "label-warning"), gr.Text(region+" behind, v"+strconv.Itoa(latest[region])))
+			}
+			el.ListItem(label).Modify(propagation)
+		}
+
+		el.Div(
+			el.Label(gr.Text("Propagation")),
+			propagation,
+		).Modify(body)
+	}
+
+	// Run rotation now
+	button := el.Button(
+		evt.Click(r.rotateButton(classType, className)).PreventDefault(),
+		gr.CSS("btn", "btn-primary"),
+		gr.Text("Run Rotation Now"),
+	)
+	if !rotate || r.State().String("rotating") != "" {
+		gr.Prop("disabled", true).Modify(button)
+	}
+	if r.State().String("rotating") == classType+"/"+className {
+		button = el.Button(gr.CSS("btn", "btn-primary"), gr.Prop("disabled", true), gr.Text("Rotating..."))
+	}
+	if helpers.CanEdit() {
+		el.Div(gr.CSS("btn-toolbar"), button).Modify(body)
+	}
+
+	return el.Div(
+		gr.CSS("panel", "panel-default"),
+		el.Div(gr.CSS("panel-heading"), el.Header4(gr.Text(className))),
+		body,
+	)
+}
+
+func retentionRow(region string, asset helpers.RotationAsset, status string) *gr.Element {
+	return el.TableRow(
+		el.TableData(gr.Text(region)),
+		el.TableData(gr.Text("v"+strconv.Itoa(asset.Version))),
+		el.TableData(gr.Text(asset.Name)),
+		el.TableData(gr.Text(asset.ID)),
+		el.TableData(gr.Text(asset.Created)),
+		el.TableData(gr.Text(status)),
+	)
+}
+
+// rotationAssets groups the live assets of a class type by class
+func (r RetentionOverview) rotationAssets(classType string) map[string][]helpers.RotationAsset {
+	byClass := make(map[string][]helpers.RotationAsset)
+
+	assetList, ok := r.State().Interface(classType).([]byte)
+	if !ok {
+		return byClass
+	}
+
+	jsonParsed, _ := gabs.ParseJSON(assetList)
+	assets, _ := jsonParsed.S("assets").Children()
+	for _, a := range assets {
+		class, _ := a.S("class").Data().(string)
+		if class == "" {
+			continue
+		}
+
+		asset := helpers.RotationAsset{
+			ID:      firstString(a, retentionIDKeys[classType]),
+			Created: firstString(a, retentionCreatedKeys[classType]),
+		}
+		asset.Name, _ = a.S("name").Data().(string)
+		asset.Region, _ = a.S("region").Data().(string)
+		asset.Version = int(classNumber(a.S("version").Data()))
+		if asset.Version == 0 {
+			asset.Version = helpers.AssetNameVersion(asset.Name)
+		}
+
+		byClass[class] = append(byClass[class], asset)
+	}
+
+	return byClass
+}
+
+// firstString returns the first of the keys that holds a string
+func firstString(container *gabs.Container, keys []string) string {
+	for _, key := range keys {
+		if s, ok := container.S(key).Data().(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func (r RetentionOverview) rotateButton(classType, className string) func(*gr.Event) {
+	return func(*gr.Event) {
+		r.SetState(gr.State{"rotating": classType + "/" + className, "error": "", "success": ""})
+
+		go func() {
+			endpoint := "//localhost:8081/api/rotate/" + classType + "/name/" + className
+			_, err := helpers.PostAPI(endpoint, map[string]interface{}{})
+			if !r.IsMounted() {
+				return
+			}
+			if err != nil {
+				r.SetState(gr.State{"rotating": "", "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+
+			r.SetState(gr.State{"rotating": "", "success": "Rotation of " + className + " was started"})
+			r.refresh()
+		}()
+	}
+}
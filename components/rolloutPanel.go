@@ -0,0 +1,422 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+const (
+	// rolloutPolls is how many times we check the autoscale groups after asking awsm to update them
+	rolloutPolls        = 100
+	rolloutPollInterval = 3 * time.Second
+)
+
+type RolloutPanel struct {
+	*gr.This
+}
+
+// launchConfigVersion is a launch configuration asset, and the autoscale groups using it
+type launchConfigVersion struct {
+	Name    string
+	Version int
+	Created string
+	UsedBy  []string
+}
+
+type launchConfigVersions []launchConfigVersion
+
+func (l launchConfigVersions) Len() int           { return len(l) }
+func (l launchConfigVersions) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l launchConfigVersions) Less(i, j int) bool { return l[i].Version > l[j].Version }
+
+// Implements the StateInitializer interface
+func (r RolloutPanel) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "bundle": nil, "launchConfigs": nil, "autoscaleGroups": nil,
+		"rolling": false, "progressClass": "", "progress": []string{}, "progressStatus": "", "progressError": "",
+	}
+}
+
+// Implements the ComponentWillMount interface
+func (r RolloutPanel) ComponentWillMount() {
+	r.SetState(gr.State{"querying": true})
+	go r.refresh()
+}
+
+// refresh reloads the classes and live assets
+func (r RolloutPanel) refresh() {
+	bundle, err := fetchClassBundle([]string{"launchconfigurations", "autoscalegroups"})
+	if !r.IsMounted() {
+		return
+	}
+	if err != nil {
+		r.SetState(gr.State{"querying": false, "error": err.Error()})
+		return
+	}
+
+	state := gr.State{"querying": false, "error": ""}
+	for key, apiType := range map[string]string{"launchConfigs": "launchconfigurations", "autoscaleGroups": "autoscalegroups"} {
+		endpoint := "//localhost:8081/api/assets/" + apiType
+		resp, err := helpers.GetAPI(endpoint)
+		if !r.IsMounted() {
+			return
+		}
+		if err != nil {
+			r.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+			return
+		}
+		state[key] = resp
+	}
+
+	bundleJson, _ := json.Marshal(bundle)
+	state["bundle"] = bundleJson
+	r.SetState(state)
+}
+
+func (r RolloutPanel) Render() gr.Component {
+
+	state := r.State()
+
+	// Panel placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return elem
+	}
+
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	versions := r.launchConfigVersions()
+
+	var classNames []string
+	for name := range bundle.Classes["launchconfigurations"] {
+		classNames = append(classNames, name)
+	}
+	sort.Strings(classNames)
+
+	if len(classNames) == 0 {
+		gr.Text("Nothing here!").Modify(response)
+		return elem
+	}
+
+	for _, className := range classNames {
+		r.buildClassPanel(className, bundle, versions[className]).Modify(response)
+	}
+
+	return elem
+}
+
+func (r RolloutPanel) buildClassPanel(className string, bundle ClassBundle, versions launchConfigVersions) *gr.Element {
+	state := r.State()
+	class := bundle.Classes["launchconfigurations"][className]
+	classVersion := int(classNumber(class["version"]))
+
+	// Autoscale group classes using this launch configuration class
+	var groupClasses []string
+	for name, groupClass := range bundle.Classes["autoscalegroups"] {
+		if fmt.Sprint(groupClass["launchConfigurationClass"]) == className {
+			groupClasses = append(groupClasses, name)
+		}
+	}
+	sort.Strings(groupClasses)
+
+	rotation := "Rotation disabled"
+	if b, _ := class["rotate"].(bool); b {
+		rotation = fmt.Sprintf("Rotating, retaining %d versions", int(classNumber(class["retain"])))
+	}
+
+	body := el.Div(gr.CSS("panel-body"))
+
+	el.Paragraph(
+		gr.Text(fmt.Sprintf("Class version %d. %s. Used by autoscale group classes: %s", classVersion, rotation, strings.Join(groupClasses, ", "))),
+	).Modify(body)
+
+	// Versions
+	tBody := el.TableBody()
+	for _, version := range versions {
+		tr := el.TableRow(
+			el.TableData(gr.Text("v"+strconv.Itoa(version.Version))),
+			el.TableData(gr.Text(version.Name)),
+			el.TableData(gr.Text(version.Created)),
+			el.TableData(gr.Text(strings.Join(version.UsedBy, ", "))),
+		)
+		if version.Version == classVersion {
+			gr.CSS("success").Modify(tr)
+		}
+		tr.Modify(tBody)
+	}
+	if len(versions) == 0 {
+		el.TableRow(el.TableData(gr.Text("No launch configurations have been created from this class yet"))).Modify(tBody)
+	}
+
+	el.Table(
+		gr.CSS("table", "table-condensed"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Version", "Launch Configuration", "Created", "Used By"})...)),
+		tBody,
+	).Modify(body)
+
+	// Roll forward / back
+	rolling := state.Bool("rolling")
+	buttons := el.Div(gr.CSS("btn-toolbar"))
+
+	if previous := previousVersion(versions, classVersion); previous > 0 {
+		button := el.Button(
+			evt.Click(r.rollButton(className, previous, groupClasses)).PreventDefault(),
+			gr.CSS("btn", "btn-default"),
+			gr.Text(fmt.Sprintf("Roll Back to v%d", previous)),
+		)
+		if rolling {
+			gr.Prop("disabled", true).Modify(button)
+		}
+		button.Modify(buttons)
+	}
+
+	forward := el.Button(
+		evt.Click(r.rollButton(className, classVersion+1, groupClasses)).PreventDefault(),
+		gr.CSS("btn", "btn-primary"),
+		gr.Text(fmt.Sprintf("Roll Forward to v%d", classVersion+1)),
+	)
+	if rolling {
+		gr.Prop("disabled", true).Modify(forward)
+	}
+	forward.Modify(buttons)
+	if helpers.CanEdit() {
+		buttons.Modify(body)
+	}
+
+	// Progress
+	if state.String("progressClass") == className {
+		progressList := el.UnorderedList(gr.CSS("list-unstyled", "rollout-progress"))
+		for _, step := range stringSliceState(state.Interface("progress")) {
+			el.ListItem(gr.Text(step)).Modify(progressList)
+		}
+		if status := state.String("progressStatus"); status != "" {
+			el.ListItem(el.Italic(gr.CSS("fa", "fa-spinner", "fa-spin")), gr.Text(" "+status)).Modify(progressList)
+		}
+		progressList.Modify(body)
+		helpers.ErrorElem(state.String("progressError")).Modify(body)
+	}
+
+	return el.Div(
+		gr.CSS("panel", "panel-default"),
+		el.Div(gr.CSS("panel-heading"), el.Header4(gr.Text(className))),
+		body,
+	)
+}
+
+// launchConfigVersions groups the live launch configurations by class, with the autoscale groups using each one
+func (r RolloutPanel) launchConfigVersions() map[string]launchConfigVersions {
+	state := r.State()
+	versions := make(map[string]launchConfigVersions)
+
+	usedBy := make(map[string][]string)
+	if groups, ok := state.Interface("autoscaleGroups").([]byte); ok {
+		jsonParsed, _ := gabs.ParseJSON(groups)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			name, _ := asset.S("name").Data().(string)
+			launchConfig, _ := asset.S("launchConfigurationName").Data().(string)
+			usedBy[launchConfig] = append(usedBy[launchConfig], name)
+		}
+	}
+
+	if launchConfigs, ok := state.Interface("launchConfigs").([]byte); ok {
+		jsonParsed, _ := gabs.ParseJSON(launchConfigs)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			name, _ := asset.S("name").Data().(string)
+			class, _ := asset.S("class").Data().(string)
+			created, _ := asset.S("creationTime").Data().(string)
+
+			version := int(classNumber(asset.S("version").Data()))
+			if version == 0 {
+				version = helpers.AssetNameVersion(name)
+			}
+			if class == "" {
+				if i := strings.LastIndex(name, "-v"); i > 0 {
+					class = name[:i]
+				}
+			}
+
+			versions[class] = append(versions[class], launchConfigVersion{
+				Name:    name,
+				Version: version,
+				Created: created,
+				UsedBy:  usedBy[name],
+			})
+		}
+	}
+
+	for class := range versions {
+		sort.Sort(versions[class])
+	}
+
+	return versions
+}
+
+// previousVersion returns the newest existing version older than the current one
+func previousVersion(versions launchConfigVersions, current int) int {
+	for _, version := range versions {
+		if version.Version < current {
+			return version.Version
+		}
+	}
+	return 0
+}
+
+func stringSliceState(v interface{}) []string {
+	var strs []string
+	switch values := v.(type) {
+	case []string:
+		strs = values
+	case []interface{}:
+		for _, value := range values {
+			strs = append(strs, fmt.Sprint(value))
+		}
+	}
+	return strs
+}
+
+func (r RolloutPanel) rollButton(className string, version int, groupClasses []string) func(*gr.Event) {
+	return func(*gr.Event) {
+		r.SetState(gr.State{"rolling": true, "progressClass": className, "progress": []string{}, "progressStatus": "", "progressError": ""})
+		go r.rollout(className, version, groupClasses)
+	}
+}
+
+// rollout sets the class version, asks awsm to update the autoscale groups, and waits for them to pick it up. Updating
+// an autoscale group class is what makes awsm build the launch configuration for the new version and switch to it
+func (r RolloutPanel) rollout(className string, version int, groupClasses []string) {
+	var progress []string
+	report := func(step string) bool {
+		progress = append(progress, step)
+		if !r.IsMounted() {
+			return false
+		}
+		r.SetState(gr.State{"progress": append([]string{}, progress...), "progressStatus": ""})
+		return true
+	}
+	status := func(line string) bool {
+		if !r.IsMounted() {
+			return false
+		}
+		r.SetState(gr.State{"progressStatus": line})
+		return true
+	}
+	fail := func(err string) {
+		if r.IsMounted() {
+			r.SetState(gr.State{"rolling": false, "progressStatus": "", "progressError": err})
+		}
+	}
+	done := func(step string) {
+		if !report(step) {
+			return
+		}
+		r.refresh()
+		if r.IsMounted() {
+			r.SetState(gr.State{"rolling": false})
+		}
+	}
+
+	// Save the class version
+	if !report(fmt.Sprintf("Setting %s to version %d", className, version)) {
+		return
+	}
+	endpoint := "//localhost:8081/api/classes/launchconfigurations/name/" + className
+	resp, err := helpers.GetAPI(endpoint)
+	if err != nil {
+		fail(fmt.Sprintf("Error while querying endpoint: %s", endpoint))
+		return
+	}
+	jsonParsed, _ := gabs.ParseJSON(resp)
+	class, ok := jsonParsed.S("class").Data().(map[string]interface{})
+	if !ok {
+		fail(fmt.Sprintf("Unable to read the %s class from endpoint: %s", className, endpoint))
+		return
+	}
+	class["version"] = version
+	if _, err := helpers.PutAPI(endpoint, class); err != nil {
+		fail(fmt.Sprintf("Error while querying endpoint: %s", endpoint))
+		return
+	}
+
+	if len(groupClasses) == 0 {
+		done("Done, no autoscale group classes use this launch configuration class")
+		return
+	}
+
+	// Update the autoscale groups, the same as awsm updateAutoScaleGroups
+	for _, groupClass := range groupClasses {
+		if !report(fmt.Sprintf("Updating the %s autoscale groups", groupClass)) {
+			return
+		}
+		endpoint = "//localhost:8081/api/update/autoscalegroups/name/" + groupClass
+		if _, err := helpers.PostAPI(endpoint, map[string]interface{}{}); err != nil {
+			fail(fmt.Sprintf("Error while querying endpoint: %s", endpoint))
+			return
+		}
+	}
+
+	// Wait for the autoscale groups to pick it up
+	target := fmt.Sprintf("%s-v%d", className, version)
+	for poll := 1; poll <= rolloutPolls; poll++ {
+		time.Sleep(rolloutPollInterval)
+
+		endpoint = "//localhost:8081/api/assets/autoscalegroups"
+		resp, err := helpers.GetAPI(endpoint)
+		if err != nil {
+			fail(fmt.Sprintf("Error while querying endpoint: %s", endpoint))
+			return
+		}
+
+		total, updated := 0, 0
+		jsonParsed, _ := gabs.ParseJSON(resp)
+		assets, _ := jsonParsed.S("assets").Children()
+		for _, asset := range assets {
+			class, _ := asset.S("class").Data().(string)
+			if !stringSet(groupClasses)[class] {
+				continue
+			}
+			total++
+			if launchConfig, _ := asset.S("launchConfigurationName").Data().(string); launchConfig == target {
+				updated++
+			}
+		}
+
+		if total == 0 {
+			done(fmt.Sprintf("Done, no autoscale groups are running yet, they will use %s when they are created", target))
+			return
+		}
+		if updated == total {
+			done(fmt.Sprintf("Done, all %d autoscale groups are using %s", total, target))
+			return
+		}
+		if !status(fmt.Sprintf("%d of %d autoscale groups are using %s", updated, total, target)) {
+			return
+		}
+	}
+
+	fail(fmt.Sprintf("Gave up waiting for the autoscale groups to use %s", target))
+}
@@ -0,0 +1,348 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+const (
+	simChartWidth   = 800
+	simChartHeight  = 240
+	simChartPadding = 30
+)
+
+type ScalingSimulator struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (s ScalingSimulator) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "bundle": nil, "group": "",
+		"shape": "ramp", "base": 20, "peak": 90, "minutes": 120, "customCurve": "",
+	}
+}
+
+// Implements the ComponentWillMount interface
+func (s ScalingSimulator) ComponentWillMount() {
+	s.SetState(gr.State{"querying": true})
+
+	go func() {
+		bundle, err := fetchClassBundle([]string{"autoscalegroups", "alarms", "scalingpolicies"})
+		if !s.IsMounted() {
+			return
+		}
+		if err != nil {
+			s.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		bundleJson, _ := json.Marshal(bundle)
+		s.SetState(gr.State{"querying": false, "bundle": bundleJson})
+	}()
+}
+
+func (s ScalingSimulator) Render() gr.Component {
+
+	state := s.State()
+
+	// Simulator placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	bundleJson, ok := state.Interface("bundle").([]byte)
+	if !ok {
+		return elem
+	}
+
+	var bundle ClassBundle
+	json.Unmarshal(bundleJson, &bundle)
+
+	var groups []string
+	for name := range bundle.Classes["autoscalegroups"] {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+
+	// Inputs
+	simForm := el.Form(evt.KeyDown(forms.DisableEnter))
+	el.Div(
+		gr.CSS("row"), el.Div(gr.CSS("col-sm-4"),
+			forms.SelectOne("Autoscale Group Class", "group", groups, state.Interface("group"), s.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			forms.SelectOne("Metric Curve", "shape", helpers.MetricShapes, state.Interface("shape"), s.storeSelect),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			forms.NumberField("Baseline", "base", state.Int("base"), s.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			forms.NumberField("Peak", "peak", state.Int("peak"), s.storeValue),
+		),
+		el.Div(gr.CSS("col-sm-2"),
+			forms.NumberField("Minutes", "minutes", state.Int("minutes"), s.storeValue),
+		),
+	).Modify(simForm)
+	forms.TextField("Custom Curve (one value per minute, overrides the curve above)", "customCurve", state.String("customCurve"), s.storeText).Modify(simForm)
+	simForm.Modify(response)
+
+	groupName := state.String("group")
+	groupClass, ok := bundle.Classes["autoscalegroups"][groupName]
+	if !ok {
+		return elem
+	}
+
+	// Metric curve
+	var curve []float64
+	if custom := strings.TrimSpace(state.String("customCurve")); custom != "" {
+		parsed, err := helpers.ParseMetricCurve(custom)
+		if err != nil {
+			helpers.ErrorElem(err.Error()).Modify(response)
+			return elem
+		}
+		curve = parsed
+	} else {
+		minutes := state.Int("minutes")
+		if minutes < 1 || minutes > helpers.MaxSimulationMinutes {
+			helpers.ErrorElem(fmt.Sprintf("Minutes must be between 1 and %d", helpers.MaxSimulationMinutes)).Modify(response)
+			return elem
+		}
+		curve = helpers.MetricCurve(state.String("shape"), float64(state.Int("base")), float64(state.Int("peak")), minutes)
+	}
+	if len(curve) == 0 {
+		return elem
+	}
+
+	group, alarms, policies, missing := buildSimulation(bundle, groupClass)
+	if len(missing) > 0 {
+		el.Div(
+			gr.CSS("alert", "alert-warning"),
+			gr.Text("Missing classes, these will be ignored: "+strings.Join(missing, ", ")),
+		).Modify(response)
+	}
+	if len(alarms) == 0 {
+		el.Div(
+			gr.CSS("alert", "alert-info"),
+			gr.Text(groupName+" has no alarms, its capacity will stay at "+strconv.Itoa(group.DesiredCapacity)),
+		).Modify(response)
+	}
+
+	steps := helpers.SimulateScaling(group, alarms, policies, curve)
+
+	BuildSimulationChart(steps, group, alarms).Modify(response)
+
+	// Events
+	tBody := el.TableBody()
+	for _, step := range steps {
+		if len(step.Events) == 0 {
+			continue
+		}
+		el.TableRow(
+			el.TableData(gr.Text(strconv.Itoa(step.Minute))),
+			el.TableData(gr.Text(strconv.FormatFloat(step.Metric, 'f', 1, 64))),
+			el.TableData(gr.Text(strconv.Itoa(step.Capacity))),
+			el.TableData(gr.Text(strings.Join(step.Events, ", "))),
+		).Modify(tBody)
+	}
+
+	el.Table(
+		gr.CSS("table", "table-striped", "table-condensed"),
+		gr.Style("width", "100%"),
+		el.TableHead(el.TableRow(helpers.BuildTableHeader([]string{"Minute", "Metric", "Capacity", "Events"})...)),
+		tBody,
+	).Modify(response)
+
+	return elem
+}
+
+// buildSimulation reads the capacity settings, alarms and scaling policies of an autoscale group class out of the bundle
+func buildSimulation(bundle ClassBundle, groupClass map[string]interface{}) (helpers.SimGroup, []helpers.SimAlarm, map[string]helpers.SimPolicy, []string) {
+	var missing []string
+
+	group := helpers.SimGroup{
+		MinSize:         int(classNumber(groupClass["minSize"])),
+		MaxSize:         int(classNumber(groupClass["maxSize"])),
+		DesiredCapacity: int(classNumber(groupClass["desiredCapacity"])),
+		DefaultCooldown: int(classNumber(groupClass["defaultCooldown"])),
+	}
+
+	var alarms []helpers.SimAlarm
+	policies := make(map[string]helpers.SimPolicy)
+
+	for _, name := range helpers.ReferencedNames(groupClass, "alarms") {
+		alarmClass, ok := bundle.Classes["alarms"][name]
+		if !ok {
+			missing = appendUniqueString(missing, "alarms/"+name)
+			continue
+		}
+
+		alarm := helpers.SimAlarm{
+			Name:               name,
+			Threshold:          classNumber(alarmClass["threshold"]),
+			ComparisonOperator: fmt.Sprint(alarmClass["comparisonOperator"]),
+			Period:             int(classNumber(alarmClass["period"])),
+			EvaluationPeriods:  int(classNumber(alarmClass["evaluationPeriods"])),
+			AlarmActions:       helpers.ReferencedNames(alarmClass, "alarmActions"),
+			OKActions:          helpers.ReferencedNames(alarmClass, "okActions"),
+		}
+		alarms = append(alarms, alarm)
+
+		for _, policyName := range append(append([]string{}, alarm.AlarmActions...), alarm.OKActions...) {
+			policyClass, ok := bundle.Classes["scalingpolicies"][policyName]
+			if !ok {
+				missing = appendUniqueString(missing, "scalingpolicies/"+policyName)
+				continue
+			}
+			policies[policyName] = helpers.SimPolicy{
+				Name:              policyName,
+				AdjustmentType:    fmt.Sprint(policyClass["adjustmentType"]),
+				ScalingAdjustment: int(classNumber(policyClass["scalingAdjustment"])),
+				Cooldown:          int(classNumber(policyClass["cooldown"])),
+			}
+		}
+	}
+
+	return group, alarms, policies, missing
+}
+
+// BuildSimulationChart draws the metric and the resulting capacity over time, with alarm thresholds and min/max size
+func BuildSimulationChart(steps []helpers.SimStep, group helpers.SimGroup, alarms []helpers.SimAlarm) *gr.Element {
+	plotWidth := float64(simChartWidth - simChartPadding*2)
+	plotHeight := float64(simChartHeight - simChartPadding*2)
+
+	// Scales
+	maxMetric := 1.0
+	for _, step := range steps {
+		if step.Metric > maxMetric {
+			maxMetric = step.Metric
+		}
+	}
+	for _, alarm := range alarms {
+		if alarm.Threshold > maxMetric {
+			maxMetric = alarm.Threshold
+		}
+	}
+	maxCapacity := group.MaxSize
+	if group.DesiredCapacity > maxCapacity {
+		maxCapacity = group.DesiredCapacity
+	}
+	if maxCapacity < 1 {
+		maxCapacity = 1
+	}
+
+	x := func(i int) float64 {
+		return simChartPadding + plotWidth*float64(i)/float64(len(steps))
+	}
+	yMetric := func(v float64) float64 {
+		return simChartPadding + plotHeight - plotHeight*v/maxMetric
+	}
+	yCapacity := func(v int) float64 {
+		return simChartPadding + plotHeight - plotHeight*float64(v)/float64(maxCapacity)
+	}
+
+	svg := gr.Elem("svg",
+		gr.CSS("scaling-chart"),
+		gr.Prop("width", "100%"),
+		gr.Prop("viewBox", fmt.Sprintf("0 0 %d %d", simChartWidth, simChartHeight)),
+	)
+
+	// Min / max size and alarm thresholds
+	simChartLine(yCapacity(group.MinSize), "scaling-chart-limit", fmt.Sprintf("min size %d", group.MinSize)).Modify(svg)
+	simChartLine(yCapacity(group.MaxSize), "scaling-chart-limit", fmt.Sprintf("max size %d", group.MaxSize)).Modify(svg)
+	for _, alarm := range alarms {
+		simChartLine(yMetric(alarm.Threshold), "scaling-chart-threshold", fmt.Sprintf("%s %s %g", alarm.Name, alarm.ComparisonOperator, alarm.Threshold)).Modify(svg)
+	}
+
+	// Metric as a line, capacity as steps
+	var metricPath, capacityPath []string
+	for i, step := range steps {
+		if i == 0 {
+			metricPath = append(metricPath, fmt.Sprintf("M %.1f %.1f", x(i), yMetric(step.Metric)))
+			capacityPath = append(capacityPath, fmt.Sprintf("M %.1f %.1f", x(i), yCapacity(step.Capacity)))
+		} else {
+			metricPath = append(metricPath, fmt.Sprintf("L %.1f %.1f", x(i), yMetric(step.Metric)))
+			capacityPath = append(capacityPath, fmt.Sprintf("H %.1f V %.1f", x(i), yCapacity(step.Capacity)))
+		}
+	}
+	capacityPath = append(capacityPath, fmt.Sprintf("H %.1f", x(len(steps))))
+
+	gr.Elem("path",
+		gr.CSS("scaling-chart-metric"),
+		gr.Prop("d", strings.Join(metricPath, " ")),
+		gr.Elem("title", gr.Text("Metric")),
+	).Modify(svg)
+	gr.Elem("path",
+		gr.CSS("scaling-chart-capacity"),
+		gr.Prop("d", strings.Join(capacityPath, " ")),
+		gr.Elem("title", gr.Text("Capacity")),
+	).Modify(svg)
+
+	// Axis labels
+	gr.Elem("text", gr.CSS("scaling-chart-label"), gr.Prop("x", 2), gr.Prop("y", simChartPadding-8),
+		gr.Text(fmt.Sprintf("capacity (max %d) / metric (max %g)", maxCapacity, maxMetric))).Modify(svg)
+	gr.Elem("text", gr.CSS("scaling-chart-label"), gr.Prop("x", simChartWidth-simChartPadding), gr.Prop("y", simChartHeight-8),
+		gr.Prop("textAnchor", "end"), gr.Text(fmt.Sprintf("%d minutes", len(steps)))).Modify(svg)
+
+	return el.Div(gr.CSS("scaling-chart-wrapper"), svg)
+}
+
+func simChartLine(y float64, css, title string) *gr.Element {
+	return gr.Elem("line",
+		gr.CSS(css),
+		gr.Prop("x1", simChartPadding),
+		gr.Prop("x2", simChartWidth-simChartPadding),
+		gr.Prop("y1", y),
+		gr.Prop("y2", y),
+		gr.Elem("title", gr.Text(title)),
+	)
+}
+
+// classNumber reads a number out of a class value, json numbers come back as floats
+func classNumber(v interface{}) float64 {
+	switch value := v.(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	}
+	return 0
+}
+
+func (s ScalingSimulator) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	s.SetState(gr.State{key: event.TargetValue().Int()})
+}
+
+func (s ScalingSimulator) storeText(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	s.SetState(gr.State{key: event.TargetValue().String()})
+}
+
+func (s ScalingSimulator) storeSelect(key string, val interface{}) {
+	if value, ok := val.(map[string]interface{}); ok {
+		s.SetState(gr.State{key: value["value"]})
+	} else {
+		s.SetState(gr.State{key: ""})
+	}
+}
@@ -0,0 +1,220 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/murdinc/awsmDashboard/components/forms"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+// TagEditor adds and removes tags on one or more assets
+type TagEditor struct {
+	*gr.This
+}
+
+// Implements the StateInitializer interface
+func (t TagEditor) GetInitialState() gr.State {
+	return gr.State{"key": "", "value": "", "add": nil, "remove": nil, "saving": false, "error": ""}
+}
+
+func (t TagEditor) Render() gr.Component {
+
+	state := t.State()
+	props := t.Props()
+
+	ids := stringSliceState(props.Interface("ids"))
+	removals := stringSliceState(state.Interface("remove"))
+	additions := stringSliceState(state.Interface("add"))
+
+	editor := el.Div(gr.CSS("tag-editor"))
+
+	el.Header4(gr.Text(fmt.Sprintf("Edit Tags on %d Assets", len(ids)))).Modify(editor)
+
+	if state.Bool("saving") {
+		gr.Text("Saving...").Modify(editor)
+		return editor
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(editor)
+
+	// Tags every selected asset shares, click to remove
+	current := el.Div(el.Label(gr.Text("Shared Tags")), gr.Text(" "))
+	tags := stringSliceState(props.Interface("tags"))
+	if len(tags) == 0 {
+		el.Italic(gr.Text("none")).Modify(current)
+	}
+	for _, tag := range tags {
+		key := helpers.ParseTagFilter(tag).Key
+		css := gr.CSS("label", "label-default", "tag-chip")
+		if containsString(removals, key) {
+			css = gr.CSS("label", "label-danger", "tag-chip", "tag-chip-removed")
+		}
+		el.Span(
+			css,
+			evt.Click(t.toggleRemove(key)),
+			gr.Text(tag+" "),
+			el.Italic(gr.CSS("fa", "fa-times")),
+		).Modify(current)
+	}
+	current.Modify(editor)
+
+	// Tags to add
+	pending := el.Div(el.Label(gr.Text("Adding")), gr.Text(" "))
+	if len(additions) == 0 {
+		el.Italic(gr.Text("none")).Modify(pending)
+	}
+	for _, tag := range additions {
+		el.Span(
+			gr.CSS("label", "label-success", "tag-chip"),
+			evt.Click(t.removeAddition(tag)),
+			gr.Text(tag+" "),
+			el.Italic(gr.CSS("fa", "fa-times")),
+		).Modify(pending)
+	}
+	pending.Modify(editor)
+
+	el.Form(
+		evt.KeyDown(forms.CaptureEnter(t.addButton)),
+		el.Div(
+			gr.CSS("row"), el.Div(gr.CSS("col-sm-5"),
+				forms.TextField("Key", "key", state.String("key"), t.storeValue),
+			),
+			el.Div(gr.CSS("col-sm-5"),
+				forms.TextField("Value", "value", state.String("value"), t.storeValue),
+			),
+			el.Div(gr.CSS("col-sm-2"),
+				el.Label(gr.Text(" ")),
+				el.Button(
+					evt.Click(t.addButton).PreventDefault(),
+					gr.CSS("btn", "btn-default", "btn-block"),
+					gr.Text("Add"),
+				),
+			),
+		),
+	).Modify(editor)
+
+	buttons := el.Div(gr.CSS("btn-toolbar"))
+
+	// Cancel
+	el.Button(
+		evt.Click(t.cancelButton).PreventDefault(),
+		gr.CSS("btn", "btn-secondary"),
+		gr.Text("Cancel"),
+	).Modify(buttons)
+
+	// Apply
+	apply := el.Button(
+		evt.Click(t.applyButton).PreventDefault(),
+		gr.CSS("btn", "btn-primary"),
+		gr.Text("Apply"),
+	)
+	if len(additions) == 0 && len(removals) == 0 {
+		gr.Prop("disabled", true).Modify(apply)
+	}
+	apply.Modify(buttons)
+
+	buttons.Modify(editor)
+
+	return editor
+}
+
+func (t TagEditor) addButton(*gr.Event) {
+	key := strings.TrimSpace(t.State().String("key"))
+	if key == "" {
+		t.SetState(gr.State{"error": "Tags need a key!"})
+		return
+	}
+	if strings.Contains(key, "=") {
+		t.SetState(gr.State{"error": "Tag keys can not contain \"=\"!"})
+		return
+	}
+
+	tag := key + "=" + strings.TrimSpace(t.State().String("value"))
+
+	// Replace any pending tag with the same key
+	var additions []string
+	for _, addition := range stringSliceState(t.State().Interface("add")) {
+		if helpers.ParseTagFilter(addition).Key != key {
+			additions = append(additions, addition)
+		}
+	}
+	additions = append(additions, tag)
+
+	t.SetState(gr.State{"add": additions, "key": "", "value": "", "error": ""})
+}
+
+func (t TagEditor) removeAddition(tag string) func(*gr.Event) {
+	return func(*gr.Event) {
+		var additions []string
+		for _, addition := range stringSliceState(t.State().Interface("add")) {
+			if addition != tag {
+				additions = append(additions, addition)
+			}
+		}
+		t.SetState(gr.State{"add": additions})
+	}
+}
+
+func (t TagEditor) toggleRemove(key string) func(*gr.Event) {
+	return func(*gr.Event) {
+		removals := stringSliceState(t.State().Interface("remove"))
+		if containsString(removals, key) {
+			var kept []string
+			for _, r := range removals {
+				if r != key {
+					kept = append(kept, r)
+				}
+			}
+			t.SetState(gr.State{"remove": kept})
+			return
+		}
+		t.SetState(gr.State{"remove": append(removals, key)})
+	}
+}
+
+func (t TagEditor) applyButton(*gr.Event) {
+	ids := stringSliceState(t.Props().Interface("ids"))
+	removals := stringSliceState(t.State().Interface("remove"))
+
+	add := make(map[string]string)
+	for _, addition := range stringSliceState(t.State().Interface("add")) {
+		tag := helpers.ParseTagFilter(addition)
+		add[tag.Key] = tag.Value
+	}
+
+	t.SetState(gr.State{"saving": true, "error": ""})
+
+	go func() {
+		err := helpers.UpdateAssetTags(t.Props().String("apiType"), ids, add, removals)
+		if !t.IsMounted() {
+			return
+		}
+		if err != nil {
+			t.SetState(gr.State{"saving": false, "error": err.Error()})
+			return
+		}
+		t.Props().Call("onDone", true)
+	}()
+}
+
+func (t TagEditor) cancelButton(*gr.Event) {
+	t.Props().Call("onDone", false)
+}
+
+func (t TagEditor) storeValue(event *gr.Event) {
+	key := event.Target().Get("name").String()
+	t.SetState(gr.State{key: event.TargetValue().String()})
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,543 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+	"github.com/bep/gr"
+	"github.com/bep/gr/el"
+	"github.com/bep/gr/evt"
+	"github.com/bep/grouter"
+	"github.com/murdinc/awsmDashboard/helpers"
+)
+
+const (
+	topologyColumnWidth = 210
+	topologyRowHeight   = 50
+	topologyNodeWidth   = 190
+	topologyNodeHeight  = 36
+	topologyPadding     = 10
+)
+
+// vpcTopologyTypes are the asset types the topology is drawn from
+var vpcTopologyTypes = []string{"vpcs", "subnets", "securitygroups", "instances", "loadbalancers"}
+
+// VpcTopology draws a VPC with its subnets, route tables, gateways, security groups, instances and load balancers
+type VpcTopology struct {
+	*gr.This
+}
+
+// topologyNode is an asset, or a route table or gateway, placed on the diagram
+type topologyNode struct {
+	Key    string
+	Kind   string
+	Label  string
+	Detail string
+	X      int
+	Y      int
+	Asset  *gabs.Container // nil for route tables and gateways, which come from subnet classes
+	Link   string
+}
+
+// topologyEdge connects two nodes by key
+type topologyEdge struct {
+	From string
+	To   string
+	Kind string
+}
+
+// topologyNodes sorts by label
+type topologyNodes []topologyNode
+
+func (t topologyNodes) Len() int           { return len(t) }
+func (t topologyNodes) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t topologyNodes) Less(i, j int) bool { return t[i].Label < t[j].Label }
+
+// Implements the StateInitializer interface
+func (v VpcTopology) GetInitialState() gr.State {
+	return gr.State{"querying": false, "error": "", "assets": nil, "subnetClasses": nil, "selected": ""}
+}
+
+// Implements the ComponentWillMount interface
+func (v VpcTopology) ComponentWillMount() {
+	v.SetState(gr.State{"querying": true})
+
+	go func() {
+		assets := make(map[string]json.RawMessage)
+		for _, assetType := range vpcTopologyTypes {
+			endpoint := "//localhost:8081/api/assets/" + assetType
+			resp, err := helpers.GetAPI(endpoint)
+			if !v.IsMounted() {
+				return
+			}
+			if err != nil {
+				v.SetState(gr.State{"querying": false, "error": fmt.Sprintf("Error while querying endpoint: %s", endpoint)})
+				return
+			}
+			assets[assetType] = resp
+		}
+
+		bundle, err := fetchClassBundle([]string{"subnets"})
+		if !v.IsMounted() {
+			return
+		}
+		if err != nil {
+			v.SetState(gr.State{"querying": false, "error": err.Error()})
+			return
+		}
+
+		assetsJson, _ := json.Marshal(assets)
+		classesJson, _ := json.Marshal(bundle.Classes["subnets"])
+		v.SetState(gr.State{"querying": false, "error": "", "assets": assetsJson, "subnetClasses": classesJson})
+	}()
+}
+
+func (v VpcTopology) Render() gr.Component {
+
+	state := v.State()
+	vpcID := v.Props().String("vpcId")
+
+	// Diagram placeholder
+	response := el.Div()
+
+	elem := el.Div(gr.CSS("content"),
+		el.Paragraph(
+			grouter.Link("/vpcs", "All Vpcs"),
+		),
+		response,
+	)
+
+	if state.Bool("querying") {
+		gr.Text("Loading...").Modify(response)
+		return elem
+	}
+
+	helpers.ErrorElem(state.String("error")).Modify(response)
+
+	assetsJson, ok := state.Interface("assets").([]byte)
+	if !ok {
+		return elem
+	}
+
+	var raw map[string]json.RawMessage
+	json.Unmarshal(assetsJson, &raw)
+	assets := make(map[string][]*gabs.Container)
+	for _, assetType := range vpcTopologyTypes {
+		jsonParsed, _ := gabs.ParseJSON(raw[assetType])
+		assets[assetType], _ = jsonParsed.S("assets").Children()
+	}
+
+	var subnetClasses map[string]map[string]interface{}
+	if classesJson, ok := state.Interface("subnetClasses").([]byte); ok {
+		json.Unmarshal(classesJson, &subnetClasses)
+	}
+
+	nodes, edges := buildTopology(vpcID, assets, subnetClasses)
+	if len(nodes) == 0 {
+		gr.Text("VPC " + vpcID + " not found!").Modify(response)
+		return elem
+	}
+
+	v.BuildDiagram(nodes, edges).Modify(response)
+
+	el.Paragraph(
+		gr.CSS("text-muted"),
+		gr.Text("Route tables and gateways are drawn from the class of each subnet. Click anything to see what it is connected to."),
+	).Modify(response)
+
+	// Details of the selected node
+	if selected := state.String("selected"); selected != "" {
+		for _, node := range nodes {
+			if node.Key == selected {
+				topologyDetails(node, nodes, edges).Modify(response)
+			}
+		}
+	}
+
+	return elem
+}
+
+// BuildDiagram draws the nodes and edges, highlighting everything connected to the selected node
+func (v VpcTopology) BuildDiagram(nodes []topologyNode, edges []topologyEdge) *gr.Element {
+
+	selected := v.State().String("selected")
+
+	byKey := make(map[string]topologyNode)
+	width, height := 0, 0
+	for _, node := range nodes {
+		byKey[node.Key] = node
+		if node.X+topologyNodeWidth+topologyPadding > width {
+			width = node.X + topologyNodeWidth + topologyPadding
+		}
+		if node.Y+topologyNodeHeight+topologyPadding > height {
+			height = node.Y + topologyNodeHeight + topologyPadding
+		}
+	}
+
+	svg := gr.Elem("svg",
+		gr.CSS("topology"),
+		gr.Prop("width", width),
+		gr.Prop("height", height),
+		gr.Prop("viewBox", fmt.Sprintf("0 0 %d %d", width, height)),
+	)
+
+	// Edges first so the nodes are drawn over them
+	connected := map[string]bool{selected: true}
+	for _, edge := range edges {
+		from, to := byKey[edge.From], byKey[edge.To]
+
+		edgeCSS := gr.CSS("topology-edge", "topology-edge-"+edge.Kind)
+		if edge.From == selected || edge.To == selected {
+			edgeCSS = gr.CSS("topology-edge", "topology-edge-"+edge.Kind, "active")
+			connected[edge.From] = true
+			connected[edge.To] = true
+		}
+
+		x1, y1 := from.X+topologyNodeWidth/2, from.Y+topologyNodeHeight/2
+		x2, y2 := to.X+topologyNodeWidth/2, to.Y+topologyNodeHeight/2
+		mid := (y1 + y2) / 2
+
+		gr.Elem("path",
+			edgeCSS,
+			gr.Prop("d", fmt.Sprintf("M %d %d C %d %d, %d %d, %d %d", x1, y1, x1, mid, x2, mid, x2, y2)),
+			gr.Elem("title", gr.Text(from.Label+" "+edge.Kind+" "+to.Label)),
+		).Modify(svg)
+	}
+
+	for _, node := range nodes {
+		nodeCSS := gr.CSS("topology-node", "topology-node-"+node.Kind)
+		switch {
+		case node.Key == selected:
+			nodeCSS = gr.CSS("topology-node", "topology-node-"+node.Kind, "active")
+		case selected != "" && !connected[node.Key]:
+			nodeCSS = gr.CSS("topology-node", "topology-node-"+node.Kind, "faded")
+		}
+
+		gr.Elem("g",
+			nodeCSS,
+			evt.Click(v.selectNode(node.Key)),
+			gr.Elem("rect",
+				gr.Prop("x", node.X),
+				gr.Prop("y", node.Y),
+				gr.Prop("width", topologyNodeWidth),
+				gr.Prop("height", topologyNodeHeight),
+			),
+			gr.Elem("text",
+				gr.CSS("topology-label"),
+				gr.Prop("x", node.X+8),
+				gr.Prop("y", node.Y+15),
+				gr.Text(node.Label),
+			),
+			gr.Elem("text",
+				gr.CSS("topology-detail"),
+				gr.Prop("x", node.X+8),
+				gr.Prop("y", node.Y+29),
+				gr.Text(node.Detail),
+			),
+		).Modify(svg)
+	}
+
+	return el.Div(
+		gr.CSS("topology-wrapper"),
+		svg,
+	)
+}
+
+func (v VpcTopology) selectNode(key string) func(*gr.Event) {
+	return func(*gr.Event) {
+		if v.State().String("selected") == key {
+			v.SetState(gr.State{"selected": ""})
+			return
+		}
+		v.SetState(gr.State{"selected": key})
+	}
+}
+
+// buildTopology lays out the VPC: gateways and the main route table beside it, a column for each subnet with its NAT
+// gateway, route table and instances, load balancers below, and security groups in a column of their own
+func buildTopology(vpcID string, assets map[string][]*gabs.Container, subnetClasses map[string]map[string]interface{}) ([]topologyNode, []topologyEdge) {
+	var nodes []topologyNode
+	var edges []topologyEdge
+
+	place := func(node topologyNode, col, row int) topologyNode {
+		node.X = topologyPadding + col*topologyColumnWidth
+		node.Y = topologyPadding + row*topologyRowHeight
+		nodes = append(nodes, node)
+		return node
+	}
+	connect := func(from, to, kind string) {
+		edges = append(edges, topologyEdge{From: from, To: to, Kind: kind})
+	}
+
+	// VPC
+	var vpc *gabs.Container
+	for _, asset := range assets["vpcs"] {
+		if assetField(asset, "vpcID", "VpcID") == vpcID {
+			vpc = asset
+		}
+	}
+	if vpc == nil {
+		return nodes, edges
+	}
+	vpcNode := place(assetNode("vpcs", "vpc", vpc, vpcID, assetField(vpc, "cidrBlock", "CIDRBlock")), 0, 0)
+
+	mainRouteTable := place(topologyNode{Key: "routetable/main", Kind: "routetable", Label: "Main Route Table", Detail: "Route Table"}, 1, 0)
+	connect(vpcNode.Key, mainRouteTable.Key, "contains")
+
+	// Subnets, one column each
+	var subnets topologyNodes
+	for _, asset := range assets["subnets"] {
+		if assetField(asset, "vpcID", "VpcID") != vpcID {
+			continue
+		}
+		id := assetField(asset, "subnetID", "SubnetID")
+		subnets = append(subnets, assetNode("subnets", "subnet", asset, id,
+			strings.TrimSpace(assetField(asset, "cidrBlock", "CIDRBlock")+" "+assetField(asset, "availabilityZone", "AvailabilityZone"))))
+	}
+	sort.Sort(subnets)
+
+	var internetGateway string
+	columnRows := make([]int, len(subnets))
+	subnetColumns := make(map[string]int)
+	for col, subnet := range subnets {
+		subnet = place(subnet, col, 2)
+		subnetID := strings.TrimPrefix(subnet.Key, "subnets/")
+		subnetColumns[subnetID] = col
+		row := 3
+		connect(vpcNode.Key, subnet.Key, "contains")
+
+		className := assetField(subnet.Asset, "class", "Class")
+		class := subnetClasses[className]
+
+		// The internet gateway belongs to the VPC, but is created by a subnet class
+		if classBool(class, "createInternetGateway") && internetGateway == "" {
+			internetGateway = place(topologyNode{Key: "internetgateway", Kind: "internetgateway", Label: "Internet Gateway", Detail: "from " + className}, 2, 0).Key
+			connect(vpcNode.Key, internetGateway, "contains")
+		}
+
+		var natGateway string
+		if classBool(class, "createNatGateway") {
+			natGateway = place(topologyNode{Key: "natgateway/" + subnetID, Kind: "natgateway", Label: "NAT Gateway", Detail: "from " + className}, col, row).Key
+			connect(subnet.Key, natGateway, "contains")
+			row++
+		}
+
+		routeTable := mainRouteTable.Key
+		if classBool(class, "addInternetGatewayToNewRouteTable") || classBool(class, "addNatGatewayToNewRouteTable") {
+			routeTable = place(topologyNode{Key: "routetable/" + subnetID, Kind: "routetable", Label: "Route Table", Detail: "from " + className}, col, row).Key
+			row++
+		}
+		connect(routeTable, subnet.Key, "routes")
+
+		for _, gateway := range []struct{ key, prefix string }{{internetGateway, "addInternetGateway"}, {natGateway, "addNatGateway"}} {
+			if gateway.key == "" {
+				continue
+			}
+			if classBool(class, gateway.prefix+"ToMainRouteTable") {
+				connect(gateway.key, mainRouteTable.Key, "routes")
+			}
+			if classBool(class, gateway.prefix+"ToNewRouteTable") && routeTable != mainRouteTable.Key {
+				connect(gateway.key, routeTable, "routes")
+			}
+		}
+
+		columnRows[col] = row
+	}
+
+	// Instances, under their subnet
+	var instances topologyNodes
+	for _, asset := range assets["instances"] {
+		if _, ok := subnetColumns[assetField(asset, "subnetID", "SubnetID")]; !ok {
+			continue
+		}
+		instances = append(instances, assetNode("instances", "instance", asset, assetField(asset, "instanceID", "InstanceID"),
+			strings.TrimSpace(assetField(asset, "instanceType", "InstanceType")+" "+assetField(asset, "privateIP", "PrivateIP"))))
+	}
+	sort.Sort(instances)
+	for _, instance := range instances {
+		subnetID := assetField(instance.Asset, "subnetID", "SubnetID")
+		col := subnetColumns[subnetID]
+		place(instance, col, columnRows[col])
+		columnRows[col]++
+		connect("subnets/"+subnetID, instance.Key, "contains")
+	}
+
+	// Load balancers, in a row below the tallest subnet
+	lbRow := 3
+	for _, rows := range columnRows {
+		if rows+1 > lbRow {
+			lbRow = rows + 1
+		}
+	}
+	var loadBalancers topologyNodes
+	for _, asset := range assets["loadbalancers"] {
+		inVpc := assetField(asset, "vpcID", "VpcID") == vpcID
+		for _, subnetID := range assetList(asset, "subnets", "Subnets", "subnetIDs") {
+			if _, ok := subnetColumns[subnetID]; ok {
+				inVpc = true
+			}
+		}
+		if inVpc {
+			loadBalancers = append(loadBalancers, assetNode("loadbalancers", "loadbalancer", asset, assetField(asset, "name", "Name"), assetField(asset, "dnsName", "DNSName")))
+		}
+	}
+	sort.Sort(loadBalancers)
+	for i, lb := range loadBalancers {
+		place(lb, i, lbRow)
+		for _, subnetID := range assetList(lb.Asset, "subnets", "Subnets", "subnetIDs") {
+			if _, ok := subnetColumns[subnetID]; ok {
+				connect(lb.Key, "subnets/"+subnetID, "serves")
+			}
+		}
+	}
+
+	// Security groups, in a column to the right of everything else
+	sgCol := 3
+	if len(subnets) > sgCol {
+		sgCol = len(subnets)
+	}
+	if len(loadBalancers) > sgCol {
+		sgCol = len(loadBalancers)
+	}
+	var securityGroups topologyNodes
+	for _, asset := range assets["securitygroups"] {
+		if assetField(asset, "vpcID", "VpcID") == vpcID {
+			securityGroups = append(securityGroups, assetNode("securitygroups", "securitygroup", asset, assetField(asset, "groupID", "GroupID"), assetField(asset, "description", "Description")))
+		}
+	}
+	sort.Sort(securityGroups)
+	for i, sg := range securityGroups {
+		place(sg, sgCol, 2+i)
+	}
+
+	// Security group membership, by group id or name
+	members := append(append(topologyNodes{}, instances...), loadBalancers...)
+	for _, member := range members {
+		groups := assetList(member.Asset, "securityGroups", "SecurityGroups", "securityGroupIDs")
+		for _, sg := range securityGroups {
+			id := strings.TrimPrefix(sg.Key, "securitygroups/")
+			if containsString(groups, id) || containsString(groups, sg.Label) {
+				connect(member.Key, sg.Key, "uses")
+			}
+		}
+	}
+
+	return nodes, edges
+}
+
+// assetNode is the node for an asset, labelled by its name and linked to its row in the asset table
+func assetNode(apiType, kind string, asset *gabs.Container, id, detail string) topologyNode {
+	label := assetField(asset, "name", "Name")
+	if label == "" {
+		label = id
+	}
+
+	node := topologyNode{Key: apiType + "/" + id, Kind: kind, Label: label, Detail: detail, Asset: asset}
+	if t, ok := FindAssetType(apiType); ok {
+		node.Link = t.Route + "?asset=" + url.QueryEscape(id)
+	}
+
+	return node
+}
+
+// topologyDetails lists the fields of the selected node and everything it is connected to
+func topologyDetails(node topologyNode, nodes []topologyNode, edges []topologyEdge) *gr.Element {
+	labels := make(map[string]string)
+	for _, n := range nodes {
+		labels[n.Key] = n.Label
+	}
+
+	heading := el.Div(gr.CSS("panel-heading"), el.Strong(gr.Text(node.Label)), gr.Text(" "+node.Detail))
+	if node.Link != "" {
+		el.Span(gr.CSS("pull-right"), grouter.Link(node.Link, "Show in table")).Modify(heading)
+	}
+
+	body := el.Div(gr.CSS("panel-body"))
+
+	// Fields
+	if node.Asset != nil {
+		fields, _ := node.Asset.ChildrenMap()
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		list := el.DescriptionList(gr.CSS("dl-horizontal"))
+		for _, key := range keys {
+			switch value := fields[key].Data().(type) {
+			case string, float64, bool:
+				el.DefinitionTerm(gr.Text(key)).Modify(list)
+				el.Description(gr.Text(fmt.Sprint(value))).Modify(list)
+			}
+		}
+		list.Modify(body)
+	}
+
+	// Connections
+	var connections []string
+	for _, edge := range edges {
+		switch node.Key {
+		case edge.From:
+			connections = append(connections, edge.Kind+" "+labels[edge.To])
+		case edge.To:
+			connections = append(connections, labels[edge.From]+" "+edge.Kind)
+		}
+	}
+	sort.Strings(connections)
+
+	connectionList := el.UnorderedList()
+	for _, connection := range connections {
+		el.ListItem(gr.Text(connection)).Modify(connectionList)
+	}
+	el.Div(el.Label(gr.Text("Connections")), connectionList).Modify(body)
+
+	return el.Div(
+		gr.CSS("panel", "panel-default", "topology-details"),
+		heading,
+		body,
+	)
+}
+
+// vpcTopologyPath is the topology page of a VPC asset
+func vpcTopologyPath(asset *gabs.Container) string {
+	return "/vpcs/" + assetField(asset, "vpcID", "VpcID")
+}
+
+// assetField returns the first of the fields the asset has, as text
+func assetField(asset *gabs.Container, keys ...string) string {
+	for _, key := range keys {
+		if value := asset.S(key).Data(); value != nil {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+// assetList returns the first of the fields the asset has, which can be a list or comma separated text
+func assetList(asset *gabs.Container, keys ...string) []string {
+	for _, key := range keys {
+		switch value := asset.S(key).Data().(type) {
+		case []interface{}:
+			return stringSliceState(value)
+		case string:
+			var list []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			return list
+		}
+	}
+	return nil
+}
+
+func classBool(class map[string]interface{}, key string) bool {
+	value, _ := class[key].(bool)
+	return value
+}
@@ -16,6 +16,11 @@ func (d WidgetDropdownMenu) triggerRefresh(event *gr.Event) {
 	d.SetState(gr.State{"nonce": time.Now()})
 }
 
+// Implements the ComponentDidMount interface
+func (d WidgetDropdownMenu) ComponentDidMount() {
+	showRequestedModal("new-widget", "edit-widgets")
+}
+
 func (d WidgetDropdownMenu) Render() gr.Component {
 
 	//state := d.State()
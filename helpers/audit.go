@@ -0,0 +1,210 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// AuditEndpoint lists every change made through the dashboard and awsm
+const AuditEndpoint = "//localhost:8081/api/audit"
+
+// Kinds of audit change
+const (
+	AuditAdded   = "added"
+	AuditRemoved = "removed"
+	AuditChanged = "changed"
+)
+
+// AuditEntry is one change recorded by the awsm API
+type AuditEntry struct {
+	ID        string      `json:"id"`
+	RequestID string      `json:"requestId"`
+	Time      string      `json:"time"`
+	User      string      `json:"user"`
+	Action    string      `json:"action"` // PUT, DELETE, POST, or an asset action like "stop"
+	Type      string      `json:"type"`   // class or asset type, like "instances" or "widgets"
+	Target    string      `json:"target"` // class, widget or asset name
+	Before    interface{} `json:"before"`
+	After     interface{} `json:"after"`
+}
+
+// AuditChange is one field that differs between the before and after of an audit entry
+type AuditChange struct {
+	Path   string
+	Kind   string
+	Before string
+	After  string
+}
+
+type auditEntries []AuditEntry
+
+func (a auditEntries) Len() int           { return len(a) }
+func (a auditEntries) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a auditEntries) Less(i, j int) bool { return a[i].Time > a[j].Time }
+
+type auditChanges []AuditChange
+
+func (a auditChanges) Len() int           { return len(a) }
+func (a auditChanges) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a auditChanges) Less(i, j int) bool { return a[i].Path < a[j].Path }
+
+// ParseAuditEntries reads the audit endpoint response, newest entries first
+func ParseAuditEntries(resp []byte) ([]AuditEntry, error) {
+	var parsed struct {
+		Entries []AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		return nil, err
+	}
+
+	sort.Sort(auditEntries(parsed.Entries))
+
+	return parsed.Entries, nil
+}
+
+// DiffAudit compares the before and after of an audit entry field by field. Lists are lined up by their unchanged
+// elements first, so an element inserted into a list is one addition rather than a change to every element after it
+func DiffAudit(before, after interface{}) []AuditChange {
+	var changes []AuditChange
+	diffAudit("", before, after, &changes)
+	sort.Sort(auditChanges(changes))
+
+	return changes
+}
+
+func diffAudit(path string, before, after interface{}, changes *[]AuditChange) {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			for key, value := range b {
+				diffAudit(auditPath(path, key), value, a[key], changes)
+			}
+			for key, value := range a {
+				if _, ok := b[key]; !ok {
+					diffAudit(auditPath(path, key), nil, value, changes)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			diffAuditLists(path, b, a, changes)
+			return
+		}
+	}
+
+	// Plain values, or a value that changed shape
+	oldFields := make(map[string]string)
+	newFields := make(map[string]string)
+	flattenAudit(path, before, oldFields)
+	flattenAudit(path, after, newFields)
+
+	for field, oldValue := range oldFields {
+		newValue, ok := newFields[field]
+		switch {
+		case !ok:
+			*changes = append(*changes, AuditChange{Path: field, Kind: AuditRemoved, Before: oldValue})
+		case newValue != oldValue:
+			*changes = append(*changes, AuditChange{Path: field, Kind: AuditChanged, Before: oldValue, After: newValue})
+		}
+	}
+	for field, newValue := range newFields {
+		if _, ok := oldFields[field]; !ok {
+			*changes = append(*changes, AuditChange{Path: field, Kind: AuditAdded, After: newValue})
+		}
+	}
+}
+
+// diffAuditLists matches up the elements two lists have in common, using the longest common subsequence. Between
+// those, elements are compared in pairs, and whatever is left over was removed or added. Removed elements keep their
+// old index in the path, everything else gets its new index
+func diffAuditLists(path string, before, after []interface{}, changes *[]AuditChange) {
+	oldKeys := make([]string, len(before))
+	for i, value := range before {
+		key, _ := json.Marshal(value)
+		oldKeys[i] = string(key)
+	}
+	newKeys := make([]string, len(after))
+	for i, value := range after {
+		key, _ := json.Marshal(value)
+		newKeys[i] = string(key)
+	}
+
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if oldKeys[i] == newKeys[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var removed, added []int
+	flush := func() {
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			switch {
+			case k >= len(added):
+				diffAudit(auditPath(path, strconv.Itoa(removed[k])), before[removed[k]], nil, changes)
+			case k >= len(removed):
+				diffAudit(auditPath(path, strconv.Itoa(added[k])), nil, after[added[k]], changes)
+			default:
+				diffAudit(auditPath(path, strconv.Itoa(added[k])), before[removed[k]], after[added[k]], changes)
+			}
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && oldKeys[i] == newKeys[j]:
+			flush()
+			i++
+			j++
+		case j == len(after) || i < len(before) && common[i+1][j] >= common[i][j+1]:
+			removed = append(removed, i)
+			i++
+		default:
+			added = append(added, j)
+			j++
+		}
+	}
+	flush()
+}
+
+func auditPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// flattenAudit turns nested objects and lists into dotted paths, "listeners.0.loadBalancerPort"
+func flattenAudit(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		for key, child := range v {
+			flattenAudit(auditPath(path, key), child, fields)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenAudit(auditPath(path, strconv.Itoa(i)), child, fields)
+		}
+	case string:
+		fields[path] = v
+	default:
+		fields[path] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func auditJSON(t *testing.T, data string) interface{} {
+	if data == "" {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatalf("json.Unmarshal(%q): %s", data, err)
+	}
+	return value
+}
+
+func TestDiffAudit(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after string
+		want          []AuditChange
+	}{
+		{
+			name:   "unchanged",
+			before: `{"instanceType": "t3.micro", "securityGroups": ["web"]}`,
+			after:  `{"instanceType": "t3.micro", "securityGroups": ["web"]}`,
+		},
+		{
+			name:   "created",
+			before: ``,
+			after:  `{"instanceType": "t3.micro", "ebsOptimized": false}`,
+			want: []AuditChange{
+				{Path: "ebsOptimized", Kind: AuditAdded, After: "false"},
+				{Path: "instanceType", Kind: AuditAdded, After: "t3.micro"},
+			},
+		},
+		{
+			name:   "deleted",
+			before: `{"retain": 5}`,
+			after:  ``,
+			want:   []AuditChange{{Path: "retain", Kind: AuditRemoved, Before: "5"}},
+		},
+		{
+			name:   "nested keys",
+			before: `{"healthCheck": {"target": "HTTP:80/", "interval": 30}, "scheme": "internal"}`,
+			after:  `{"healthCheck": {"target": "HTTP:80/health", "timeout": 5}, "scheme": "internal"}`,
+			want: []AuditChange{
+				{Path: "healthCheck.interval", Kind: AuditRemoved, Before: "30"},
+				{Path: "healthCheck.target", Kind: AuditChanged, Before: "HTTP:80/", After: "HTTP:80/health"},
+				{Path: "healthCheck.timeout", Kind: AuditAdded, After: "5"},
+			},
+		},
+		{
+			name:   "list element changed",
+			before: `{"listeners": [{"loadBalancerPort": 80, "protocol": "HTTP"}, {"loadBalancerPort": 443, "protocol": "HTTPS"}]}`,
+			after:  `{"listeners": [{"loadBalancerPort": 80, "protocol": "HTTP"}, {"loadBalancerPort": 8443, "protocol": "HTTPS"}]}`,
+			want:   []AuditChange{{Path: "listeners.1.loadBalancerPort", Kind: AuditChanged, Before: "443", After: "8443"}},
+		},
+		{
+			name:   "list insertion",
+			before: `{"securityGroups": ["web", "ssh", "monitoring"]}`,
+			after:  `{"securityGroups": ["lb", "web", "ssh", "monitoring"]}`,
+			want:   []AuditChange{{Path: "securityGroups.0", Kind: AuditAdded, After: "lb"}},
+		},
+		{
+			name:   "list removal",
+			before: `{"securityGroups": ["web", "ssh", "monitoring"]}`,
+			after:  `{"securityGroups": ["web", "monitoring"]}`,
+			want:   []AuditChange{{Path: "securityGroups.1", Kind: AuditRemoved, Before: "ssh"}},
+		},
+		{
+			name:   "list insertion before a changed element",
+			before: `{"grants": [{"port": 22}, {"port": 80}]}`,
+			after:  `{"grants": [{"port": 443}, {"port": 22}, {"port": 8080}]}`,
+			want: []AuditChange{
+				{Path: "grants.0.port", Kind: AuditAdded, After: "443"},
+				{Path: "grants.2.port", Kind: AuditChanged, Before: "80", After: "8080"},
+			},
+		},
+		{
+			name:   "list replaced",
+			before: `{"cidrIPs": ["10.0.0.0/16"]}`,
+			after:  `{"cidrIPs": ["10.1.0.0/16", "10.2.0.0/16"]}`,
+			want: []AuditChange{
+				{Path: "cidrIPs.0", Kind: AuditChanged, Before: "10.0.0.0/16", After: "10.1.0.0/16"},
+				{Path: "cidrIPs.1", Kind: AuditAdded, After: "10.2.0.0/16"},
+			},
+		},
+		{
+			name:   "shape changed",
+			before: `{"tags": {"env": "prod"}}`,
+			after:  `{"tags": "env=prod"}`,
+			want: []AuditChange{
+				{Path: "tags", Kind: AuditAdded, After: "env=prod"},
+				{Path: "tags.env", Kind: AuditRemoved, Before: "prod"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got := DiffAudit(auditJSON(t, test.before), auditJSON(t, test.after))
+		if len(got) == 0 && len(test.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: DiffAudit() = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestParseAuditEntries(t *testing.T) {
+	entries, err := ParseAuditEntries([]byte(`{"entries": [{"id": "1", "time": "2017-01-01T10:00:00Z"}, {"id": "3", "time": "2017-01-03T10:00:00Z"}, {"id": "2", "time": "2017-01-02T10:00:00Z"}]}`))
+	if err != nil {
+		t.Fatalf("ParseAuditEntries(): %s", err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	if want := []string{"3", "2", "1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ParseAuditEntries() ids = %v, want newest first %v", ids, want)
+	}
+
+	if _, err := ParseAuditEntries([]byte(`not json`)); err == nil {
+		t.Errorf("ParseAuditEntries() on bad json did not return an error")
+	}
+}
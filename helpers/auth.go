@@ -0,0 +1,260 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Roles the awsm API can grant a dashboard user
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+const (
+	// ApiHost serves the awsm API, only requests to it under /api/ carry the session token
+	ApiHost = "localhost:8081"
+
+	// AuthEndpoint exchanges an API token or an OIDC id token for a dashboard session
+	AuthEndpoint = "//localhost:8081/api/auth/session"
+
+	// OidcIssuer is the local stand-in identity provider
+	OidcIssuer   = "//localhost:5556/dex"
+	OidcClientID = "awsm-dashboard"
+
+	sessionKey    = "awsmSession"
+	oidcStateKey  = "awsmOidcState"
+	oidcNonceKey  = "awsmOidcNonce"
+	oidcCallback  = "/login"
+	authHeaderKey = "Authorization"
+)
+
+// ErrUnauthorized is returned by the API helpers when the session is missing, expired or revoked
+var ErrUnauthorized = errors.New("Not signed in, or your session has expired!")
+
+// ErrReadOnly is returned when the signed in user's role may not make the change
+var ErrReadOnly = errors.New("Your role does not allow this!")
+
+// Session is the signed in dashboard user
+type Session struct {
+	Token string   `json:"token"`
+	User  string   `json:"user"`
+	Roles []string `json:"roles"`
+}
+
+// HasRole returns true if the session was granted the role
+func (s *Session) HasRole(role string) bool {
+	if s == nil {
+		return false
+	}
+	for _, r := range s.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanEdit returns true if the session may create, change or delete classes, widgets and assets
+func (s *Session) CanEdit() bool {
+	return s.HasRole(RoleAdmin) || s.HasRole(RoleEditor)
+}
+
+// CurrentSession returns the session saved in local storage, or nil when signed out
+func CurrentSession() *Session {
+	stored := js.Global.Get("localStorage").Call("getItem", sessionKey)
+	if stored == nil || stored == js.Undefined {
+		return nil
+	}
+
+	var session Session
+	if err := json.Unmarshal([]byte(stored.String()), &session); err != nil || session.Token == "" {
+		return nil
+	}
+	return &session
+}
+
+// CanEdit returns true if the current user may make changes, read-only users and signed out visitors may not
+func CanEdit() bool {
+	return CurrentSession().CanEdit()
+}
+
+// SaveSession stores the session in local storage
+func SaveSession(session *Session) {
+	data, _ := json.Marshal(session)
+	js.Global.Get("localStorage").Call("setItem", sessionKey, string(data))
+}
+
+// ClearSession signs the current user out
+func ClearSession() {
+	js.Global.Get("localStorage").Call("removeItem", sessionKey)
+}
+
+// SignInWithToken exchanges an awsm API token for a session
+func SignInWithToken(token string) (*Session, error) {
+	return signIn(map[string]interface{}{"token": token})
+}
+
+// SignInWithIDToken exchanges an id token from the OIDC provider for a session
+func SignInWithIDToken(idToken string) (*Session, error) {
+	return signIn(map[string]interface{}{"idToken": idToken})
+}
+
+func signIn(data map[string]interface{}) (*Session, error) {
+	resp, err := PostAPI(AuthEndpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(resp, &session); err != nil {
+		return nil, err
+	}
+	if session.Token == "" {
+		return nil, errors.New("The awsm API did not return a session!")
+	}
+
+	SaveSession(&session)
+	return &session, nil
+}
+
+// OidcLoginURL is where to send the browser to sign in with the OIDC provider, using the implicit flow. The state and
+// nonce are kept in session storage until the provider redirects back
+func OidcLoginURL() (string, error) {
+	state, err := oidcRandom()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidcRandom()
+	if err != nil {
+		return "", err
+	}
+
+	storage := js.Global.Get("sessionStorage")
+	storage.Call("setItem", oidcStateKey, state)
+	storage.Call("setItem", oidcNonceKey, nonce)
+
+	location := js.Global.Get("location")
+	params := url.Values{
+		"client_id":     {OidcClientID},
+		"response_type": {"id_token"},
+		"scope":         {"openid profile email groups"},
+		"redirect_uri":  {location.Get("protocol").String() + "//" + location.Get("host").String() + oidcCallback},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+
+	return OidcIssuer + "/auth?" + params.Encode(), nil
+}
+
+// OidcCallback returns the id token from the provider's redirect back to the dashboard, if there is one. The state and
+// the token's nonce claim must match the ones OidcLoginURL saved
+func OidcCallback() (string, error) {
+	hash := strings.TrimPrefix(js.Global.Get("location").Get("hash").String(), "#")
+	if hash == "" {
+		return "", nil
+	}
+
+	params, err := url.ParseQuery(hash)
+	if err != nil {
+		return "", err
+	}
+	if errStr := params.Get("error"); errStr != "" {
+		return "", errors.New("Sign in failed: " + errStr)
+	}
+
+	idToken := params.Get("id_token")
+	if idToken == "" {
+		return "", nil
+	}
+
+	storage := js.Global.Get("sessionStorage")
+	state := storage.Call("getItem", oidcStateKey)
+	nonce := storage.Call("getItem", oidcNonceKey)
+	storage.Call("removeItem", oidcStateKey)
+	storage.Call("removeItem", oidcNonceKey)
+
+	if state == nil || state == js.Undefined || state.String() == "" || state.String() != params.Get("state") {
+		return "", errors.New("Sign in failed: the state returned by the identity provider does not match!")
+	}
+	if nonce == nil || nonce == js.Undefined || nonce.String() == "" || nonce.String() != IDTokenNonce(idToken) {
+		return "", errors.New("Sign in failed: the nonce in the id token does not match!")
+	}
+
+	return idToken, nil
+}
+
+// IDTokenNonce returns the nonce claim of an id token, or "" if it has none. The signature is not checked here, the awsm
+// API verifies the token when it is exchanged for a session
+func IDTokenNonce(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Nonce
+}
+
+// oidcRandom returns an unguessable value for the OIDC state and nonce
+func oidcRandom() (string, error) {
+	value := make([]byte, 32)
+	if _, err := rand.Read(value); err != nil {
+		return "", errors.New("Unable to start signing in: " + err.Error())
+	}
+	return hex.EncodeToString(value), nil
+}
+
+// authorize adds the session token to a request for the awsm API, requests anywhere else never get it
+func authorize(req *http.Request) {
+	if !isApiRequest(req) {
+		return
+	}
+	if session := CurrentSession(); session != nil {
+		req.Header.Set(authHeaderKey, "Bearer "+session.Token)
+	}
+}
+
+// isApiRequest returns true if the request is for the awsm API
+func isApiRequest(req *http.Request) bool {
+	if req.URL == nil || req.URL.Host != ApiHost {
+		return false
+	}
+	if req.URL.Scheme != "" && req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return false
+	}
+	clean := path.Clean(req.URL.Path)
+	return clean == "/api" || strings.HasPrefix(clean, "/api/")
+}
+
+// checkAuthorized drops the session if the API no longer accepts it
+func checkAuthorized(resp *http.Response) error {
+	if resp.StatusCode == http.StatusUnauthorized {
+		ClearSession()
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		return ErrReadOnly
+	}
+	return nil
+}
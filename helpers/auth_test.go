@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+)
+
+func TestIsApiRequest(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{url: "//localhost:8081/api/assets/instances", want: true},
+		{url: "http://localhost:8081/api/classes/vpcs", want: true},
+		{url: "https://localhost:8081/api/auth/session", want: true},
+		{url: "//localhost:8081/", want: false},
+		{url: "//localhost:8081/apis/instances", want: false},
+		{url: "//localhost:8081/api/../metrics", want: false},
+		{url: "//localhost:8082/api/assets/instances", want: false},
+		{url: "//localhost.example.com:8081/api/assets", want: false},
+		{url: "https://api.ipify.org/", want: false},
+		{url: "//localhost:5556/dex/token", want: false},
+		{url: "ftp://localhost:8081/api/assets", want: false},
+	}
+
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", test.url, nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest(%q): %s", test.url, err)
+		}
+		if got := isApiRequest(req); got != test.want {
+			t.Errorf("isApiRequest(%q) = %t, want %t", test.url, got, test.want)
+		}
+	}
+}
+
+func TestIDTokenNonce(t *testing.T) {
+	token := func(payload string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+	}
+
+	tests := []struct {
+		idToken string
+		want    string
+	}{
+		{idToken: token(`{"sub":"dev","nonce":"abc123"}`), want: "abc123"},
+		{idToken: token(`{"sub":"dev"}`), want: ""},
+		{idToken: token(`not json`), want: ""},
+		{idToken: "eyJhbGciOiJSUzI1NiJ9.!!!.c2lnbmF0dXJl", want: ""},
+		{idToken: "only.two", want: ""},
+		{idToken: "", want: ""},
+	}
+
+	for _, test := range tests {
+		if got := IDTokenNonce(test.idToken); got != test.want {
+			t.Errorf("IDTokenNonce(%q) = %q, want %q", test.idToken, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// CidrBlock is an IPv4 network as a range of addresses
+type CidrBlock struct {
+	Cidr   string
+	Start  uint32
+	End    uint32
+	Prefix int
+}
+
+// Size returns the number of addresses in the block
+func (c CidrBlock) Size() uint64 {
+	return uint64(c.End) - uint64(c.Start) + 1
+}
+
+// Contains reports if other falls entirely within this block
+func (c CidrBlock) Contains(other CidrBlock) bool {
+	return other.Start >= c.Start && other.End <= c.End
+}
+
+// Overlaps reports if the two blocks share any addresses
+func (c CidrBlock) Overlaps(other CidrBlock) bool {
+	return c.Start <= other.End && other.Start <= c.End
+}
+
+// ParseCidrBlock parses an IPv4 cidr, the address must be the network address
+func ParseCidrBlock(cidr string) (CidrBlock, error) {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil {
+		return CidrBlock{}, fmt.Errorf("%s is not a valid CIDR", cidr)
+	}
+
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return CidrBlock{}, fmt.Errorf("%s is not an IPv4 CIDR", cidr)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return CidrBlock{}, fmt.Errorf("%s has host bits set, did you mean %s?", cidr, ipNet.String())
+	}
+
+	prefix, _ := ipNet.Mask.Size()
+	return newCidrBlock(ipToUint32(ip4), prefix), nil
+}
+
+// FreeCidrBlocks returns the largest aligned blocks of parent that aren't used
+func FreeCidrBlocks(parent CidrBlock, used []CidrBlock) []CidrBlock {
+	var free []CidrBlock
+
+	sort.Sort(cidrBlocks(used))
+
+	next := uint64(parent.Start)
+	for _, u := range used {
+		if !parent.Overlaps(u) {
+			continue
+		}
+		if uint64(u.Start) > next {
+			free = append(free, rangeToCidrBlocks(next, uint64(u.Start)-1)...)
+		}
+		if uint64(u.End)+1 > next {
+			next = uint64(u.End) + 1
+		}
+	}
+	if next <= uint64(parent.End) {
+		free = append(free, rangeToCidrBlocks(next, uint64(parent.End))...)
+	}
+
+	return free
+}
+
+// NextCidrBlocks suggests the first count unused blocks of the given prefix length within parent
+func NextCidrBlocks(parent CidrBlock, used []CidrBlock, prefix, count int) ([]CidrBlock, error) {
+	if prefix < parent.Prefix || prefix > 32 {
+		return nil, fmt.Errorf("A /%d does not fit inside %s", prefix, parent.Cidr)
+	}
+
+	var blocks []CidrBlock
+	size := uint64(1) << uint(32-prefix)
+
+	for start := uint64(parent.Start); start+size-1 <= uint64(parent.End) && len(blocks) < count; start += size {
+		block := newCidrBlock(uint32(start), prefix)
+
+		taken := false
+		for _, u := range append(used, blocks...) {
+			if block.Overlaps(u) {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			blocks = append(blocks, block)
+		}
+	}
+
+	if len(blocks) < count {
+		return blocks, errors.New("Not enough free space left for the requested subnets")
+	}
+
+	return blocks, nil
+}
+
+func newCidrBlock(start uint32, prefix int) CidrBlock {
+	size := uint64(1) << uint(32-prefix)
+	start = uint32(uint64(start) &^ (size - 1))
+
+	return CidrBlock{
+		Cidr:   fmt.Sprintf("%s/%d", uint32ToIP(start).String(), prefix),
+		Start:  start,
+		End:    uint32(uint64(start) + size - 1),
+		Prefix: prefix,
+	}
+}
+
+// rangeToCidrBlocks splits an address range into the fewest aligned blocks
+func rangeToCidrBlocks(start, end uint64) []CidrBlock {
+	var blocks []CidrBlock
+
+	for start <= end {
+		prefix := 32
+		for prefix > 0 {
+			size := uint64(1) << uint(32-prefix+1)
+			if start%size != 0 || start+size-1 > end {
+				break
+			}
+			prefix--
+		}
+
+		block := newCidrBlock(uint32(start), prefix)
+		blocks = append(blocks, block)
+		start = uint64(block.End) + 1
+	}
+
+	return blocks
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func uint32ToIP(n uint32) net.IP {
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+type cidrBlocks []CidrBlock
+
+func (c cidrBlocks) Len() int           { return len(c) }
+func (c cidrBlocks) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c cidrBlocks) Less(i, j int) bool { return c[i].Start < c[j].Start }
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustParseCidrBlock(t *testing.T, cidr string) CidrBlock {
+	block, err := ParseCidrBlock(cidr)
+	if err != nil {
+		t.Fatalf("ParseCidrBlock(%q): %s", cidr, err)
+	}
+	return block
+}
+
+func cidrStrings(blocks []CidrBlock) []string {
+	var cidrs []string
+	for _, block := range blocks {
+		cidrs = append(cidrs, block.Cidr)
+	}
+	return cidrs
+}
+
+func TestParseCidrBlock(t *testing.T) {
+	tests := []struct {
+		cidr   string
+		want   string
+		size   uint64
+		errors bool
+	}{
+		{cidr: "10.0.0.0/16", want: "10.0.0.0/16", size: 65536},
+		{cidr: " 192.168.1.0/24 ", want: "192.168.1.0/24", size: 256},
+		{cidr: "10.0.0.5/32", want: "10.0.0.5/32", size: 1},
+		{cidr: "0.0.0.0/0", want: "0.0.0.0/0", size: 1 << 32},
+		{cidr: "10.0.0.1/16", errors: true},
+		{cidr: "10.0.0.0", errors: true},
+		{cidr: "10.0.0.0/33", errors: true},
+		{cidr: "2001:db8::/32", errors: true},
+	}
+
+	for _, test := range tests {
+		block, err := ParseCidrBlock(test.cidr)
+		if test.errors {
+			if err == nil {
+				t.Errorf("ParseCidrBlock(%q) = %v, want an error", test.cidr, block)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseCidrBlock(%q): %s", test.cidr, err)
+			continue
+		}
+		if block.Cidr != test.want || block.Size() != test.size {
+			t.Errorf("ParseCidrBlock(%q) = %s with %d addresses, want %s with %d", test.cidr, block.Cidr, block.Size(), test.want, test.size)
+		}
+	}
+}
+
+func TestCidrBlockContainsOverlaps(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		contains bool
+		overlaps bool
+	}{
+		{a: "10.0.0.0/16", b: "10.0.1.0/24", contains: true, overlaps: true},
+		{a: "10.0.1.0/24", b: "10.0.0.0/16", contains: false, overlaps: true},
+		{a: "10.0.0.0/24", b: "10.0.0.0/24", contains: true, overlaps: true},
+		{a: "10.0.0.0/24", b: "10.0.1.0/24", contains: false, overlaps: false},
+		{a: "10.0.0.0/23", b: "10.0.1.128/25", contains: true, overlaps: true},
+	}
+
+	for _, test := range tests {
+		a, b := mustParseCidrBlock(t, test.a), mustParseCidrBlock(t, test.b)
+		if got := a.Contains(b); got != test.contains {
+			t.Errorf("%s.Contains(%s) = %t, want %t", test.a, test.b, got, test.contains)
+		}
+		if got := a.Overlaps(b); got != test.overlaps {
+			t.Errorf("%s.Overlaps(%s) = %t, want %t", test.a, test.b, got, test.overlaps)
+		}
+	}
+}
+
+func TestFreeCidrBlocks(t *testing.T) {
+	tests := []struct {
+		parent string
+		used   []string
+		want   []string
+	}{
+		{parent: "10.0.0.0/24", want: []string{"10.0.0.0/24"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.0/24"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.0/25"}, want: []string{"10.0.0.128/25"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.64/26"}, want: []string{"10.0.0.0/26", "10.0.0.128/25"}},
+		{parent: "10.0.0.0/22", used: []string{"10.0.2.0/24", "10.0.0.0/24"}, want: []string{"10.0.1.0/24", "10.0.3.0/24"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.0/25", "10.0.0.0/26"}, want: []string{"10.0.0.128/25"}},
+		{parent: "10.0.0.0/24", used: []string{"192.168.0.0/16"}, want: []string{"10.0.0.0/24"}},
+	}
+
+	for _, test := range tests {
+		var used []CidrBlock
+		for _, cidr := range test.used {
+			used = append(used, mustParseCidrBlock(t, cidr))
+		}
+
+		got := cidrStrings(FreeCidrBlocks(mustParseCidrBlock(t, test.parent), used))
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("FreeCidrBlocks(%s, %v) = %v, want %v", test.parent, test.used, got, test.want)
+		}
+	}
+}
+
+func TestNextCidrBlocks(t *testing.T) {
+	tests := []struct {
+		parent string
+		used   []string
+		prefix int
+		count  int
+		want   []string
+		errors bool
+	}{
+		{parent: "10.0.0.0/16", prefix: 24, count: 2, want: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+		{parent: "10.0.0.0/16", used: []string{"10.0.0.0/24", "10.0.2.0/23"}, prefix: 24, count: 2, want: []string{"10.0.1.0/24", "10.0.4.0/24"}},
+		{parent: "10.0.0.0/24", used: []string{"10.0.0.0/25"}, prefix: 25, count: 2, want: []string{"10.0.0.128/25"}, errors: true},
+		{parent: "10.0.0.0/24", prefix: 16, count: 1, errors: true},
+		{parent: "10.0.0.0/24", prefix: 33, count: 1, errors: true},
+	}
+
+	for _, test := range tests {
+		var used []CidrBlock
+		for _, cidr := range test.used {
+			used = append(used, mustParseCidrBlock(t, cidr))
+		}
+
+		blocks, err := NextCidrBlocks(mustParseCidrBlock(t, test.parent), used, test.prefix, test.count)
+		if (err != nil) != test.errors {
+			t.Errorf("NextCidrBlocks(%s, %v, /%d, %d) error = %v, want error %t", test.parent, test.used, test.prefix, test.count, err, test.errors)
+		}
+		if got := cidrStrings(blocks); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("NextCidrBlocks(%s, %v, /%d, %d) = %v, want %v", test.parent, test.used, test.prefix, test.count, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Jeffail/gabs"
+)
+
+// ClassReference describes a class field that points at classes of another type
+type ClassReference struct {
+	FromType string
+	Field    string
+	ToType   string
+}
+
+var ClassReferences = []ClassReference{
+	{"autoscalegroups", "launchConfigurationClass", "launchconfigurations"},
+	{"autoscalegroups", "loadBalancerNames", "loadbalancers"},
+	{"autoscalegroups", "alarms", "alarms"},
+	{"autoscalegroups", "subnetClass", "subnets"},
+	{"launchconfigurations", "instanceClass", "instances"},
+	{"loadbalancers", "vpc", "vpcs"},
+	{"loadbalancers", "subnets", "subnets"},
+	{"loadbalancers", "securityGroups", "securitygroups"},
+	{"instances", "securityGroups", "securitygroups"},
+	{"instances", "ebsVolumes", "volumes"},
+	{"instances", "vpc", "vpcs"},
+	{"instances", "subnet", "subnets"},
+	{"instances", "ami", "images"},
+	{"instances", "keyName", "keypairs"},
+	{"alarms", "alarmActions", "scalingpolicies"},
+	{"alarms", "okActions", "scalingpolicies"},
+	{"alarms", "insufficientDataActions", "scalingpolicies"},
+	{"volumes", "snapshot", "snapshots"},
+}
+
+// ClassLink is a resolved reference between two classes
+type ClassLink struct {
+	FromType string
+	FromName string
+	Field    string
+	ToType   string
+	ToName   string
+}
+
+// ClassAsset is a live asset that was created from a class
+type ClassAsset struct {
+	ID   string
+	Name string
+}
+
+// ReferencedNames returns the class names a class field points at, the field can hold a single name or a list of names
+func ReferencedNames(class map[string]interface{}, field string) []string {
+	var names []string
+
+	switch value := class[field].(type) {
+	case string:
+		if value != "" {
+			names = append(names, value)
+		}
+	case []interface{}:
+		for _, v := range value {
+			if name, ok := v.(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+	case []string:
+		for _, name := range value {
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// FindClassReferrers returns every class that references the named class
+func FindClassReferrers(classType, className string) ([]ClassLink, error) {
+	var links []ClassLink
+	classLists := make(map[string]map[string]map[string]interface{})
+
+	for _, ref := range ClassReferences {
+		if ref.ToType != classType {
+			continue
+		}
+
+		classes, ok := classLists[ref.FromType]
+		if !ok {
+			endpoint := "//localhost:8081/api/classes/" + ref.FromType
+			resp, err := GetAPI(endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("Error while querying endpoint: %s", endpoint)
+			}
+
+			var classList struct {
+				Classes map[string]map[string]interface{} `json:"classes"`
+			}
+			json.Unmarshal(resp, &classList)
+
+			classes = classList.Classes
+			classLists[ref.FromType] = classes
+		}
+
+		names := make([]string, 0, len(classes))
+		for name := range classes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			for _, target := range ReferencedNames(classes[name], ref.Field) {
+				if target == className {
+					links = append(links, ClassLink{FromType: ref.FromType, FromName: name, Field: ref.Field, ToType: classType, ToName: className})
+				}
+			}
+		}
+	}
+
+	return links, nil
+}
+
+// FindClassAssets returns the live assets that were created from the named class
+func FindClassAssets(classType, className string) ([]ClassAsset, error) {
+	var assets []ClassAsset
+
+	endpoint := "//localhost:8081/api/assets/" + classType
+	resp, err := GetAPI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error while querying endpoint: %s", endpoint)
+	}
+
+	jsonParsed, _ := gabs.ParseJSON(resp)
+	children, _ := jsonParsed.S("assets").Children()
+
+	for _, asset := range children {
+		if class, _ := asset.S("class").Data().(string); class != className {
+			continue
+		}
+
+		name, _ := asset.S("name").Data().(string)
+		id := ""
+		for _, key := range []string{"instanceID", "volumeID", "imageID", "snapshotID", "vpcID", "subnetID", "groupID", "keyName", "loadBalancerName", "launchConfigurationName", "autoScaleGroupName", "alarmName", "policyName"} {
+			if v, ok := asset.S(key).Data().(string); ok && v != "" {
+				id = v
+				break
+			}
+		}
+
+		assets = append(assets, ClassAsset{ID: id, Name: name})
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"errors"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// DownloadFile hands data to the browser as a file download
+func DownloadFile(fileName, contentType string, data []byte) {
+	blob := js.Global.Get("Blob").New([]interface{}{string(data)}, map[string]interface{}{"type": contentType})
+	url := js.Global.Get("URL").Call("createObjectURL", blob)
+
+	anchor := js.Global.Get("document").Call("createElement", "a")
+	anchor.Set("href", url)
+	anchor.Set("download", fileName)
+
+	body := js.Global.Get("document").Get("body")
+	body.Call("appendChild", anchor)
+	anchor.Call("click")
+	body.Call("removeChild", anchor)
+
+	js.Global.Get("URL").Call("revokeObjectURL", url)
+}
+
+// ReadFile reads a browser File object as text, it blocks so call it from a goroutine
+func ReadFile(file *js.Object) ([]byte, error) {
+	if file == nil || file == js.Undefined {
+		return nil, errors.New("No file selected!")
+	}
+
+	done := make(chan error, 1)
+	reader := js.Global.Get("FileReader").New()
+
+	reader.Set("onload", func() {
+		done <- nil
+	})
+	reader.Set("onerror", func() {
+		done <- errors.New("Unable to read file: " + file.Get("name").String())
+	})
+	reader.Call("readAsText", file)
+
+	if err := <-done; err != nil {
+		return nil, err
+	}
+
+	return []byte(reader.Get("result").String()), nil
+}
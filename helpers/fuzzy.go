@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether every character of the query appears in order in the text, and scores the match. Higher
+// scores are better: consecutive characters, matches at the start of words and shorter texts all count for more
+func FuzzyMatch(query, text string) (int, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 0, true
+	}
+
+	original := []rune(text)
+	lower := make([]rune, len(original))
+	for i, r := range original {
+		lower[i] = unicode.ToLower(r)
+	}
+	queryRunes := []rune(strings.Join(strings.Fields(query), ""))
+
+	score := 0
+	q := 0
+	streak := 0
+	for i := 0; i < len(lower) && q < len(queryRunes); i++ {
+		if lower[i] != queryRunes[q] {
+			streak = 0
+			continue
+		}
+
+		score++
+		streak++
+		score += streak * 2
+		if i == 0 || !unicode.IsLetter(original[i-1]) && !unicode.IsDigit(original[i-1]) || unicode.IsUpper(original[i]) && unicode.IsLower(original[i-1]) {
+			score += 8 // start of a word
+		}
+		q++
+	}
+
+	if q < len(queryRunes) {
+		return 0, false
+	}
+
+	if strings.Contains(strings.ToLower(text), query) {
+		score += 20
+	}
+
+	return score*100/(len(lower)+10) + score, true
+}
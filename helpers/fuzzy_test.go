@@ -0,0 +1,65 @@
+package helpers
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        bool
+	}{
+		{query: "", text: "Instances", want: true},
+		{query: "  ", text: "", want: true},
+		{query: "inst", text: "Instances", want: true},
+		{query: "INST", text: "instances", want: true},
+		{query: "icls", text: "Instance Classes", want: true},
+		{query: "new inst", text: "New Instance Class", want: true},
+		{query: "i-0ab", text: "i-0abc123 web-v3", want: true},
+		{query: "tsni", text: "Instances", want: false},
+		{query: "instancess", text: "Instances", want: false},
+		{query: "x", text: "", want: false},
+	}
+
+	for _, test := range tests {
+		if _, ok := FuzzyMatch(test.query, test.text); ok != test.want {
+			t.Errorf("FuzzyMatch(%q, %q) matched = %t, want %t", test.query, test.text, ok, test.want)
+		}
+	}
+
+	if score, _ := FuzzyMatch("", "Instances"); score != 0 {
+		t.Errorf("FuzzyMatch with an empty query scored %d, want 0", score)
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	tests := []struct {
+		query         string
+		better, worse string
+	}{
+		// consecutive characters beat scattered ones
+		{query: "vpc", better: "Vpcs", worse: "Volume Policies"},
+		// a substring beats a match spread across words
+		{query: "sub", better: "Subnets", worse: "Scaling Update Bucket"},
+		// starts of words beat the middle of one
+		{query: "lb", better: "Load Balancers", worse: "Global"},
+		// camel case counts as a word start
+		{query: "lc", better: "launchConfig", worse: "angelic"},
+		// shorter texts win on equal matches
+		{query: "alarm", better: "Alarms", worse: "Alarms for the web autoscale group"},
+	}
+
+	for _, test := range tests {
+		better, ok := FuzzyMatch(test.query, test.better)
+		if !ok {
+			t.Errorf("FuzzyMatch(%q, %q) did not match", test.query, test.better)
+			continue
+		}
+		worse, ok := FuzzyMatch(test.query, test.worse)
+		if !ok {
+			t.Errorf("FuzzyMatch(%q, %q) did not match", test.query, test.worse)
+			continue
+		}
+		if better <= worse {
+			t.Errorf("FuzzyMatch(%q) scored %q %d and %q %d, want the first higher", test.query, test.better, better, test.worse, worse)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package helpers
+
+import (
+	"sort"
+	"strings"
+)
+
+// EBS optimization support of an instance type
+const (
+	EbsOptimizedUnsupported = "unsupported"
+	EbsOptimizedSupported   = "supported"
+	EbsOptimizedDefault     = "default" // always on, can't be turned off
+)
+
+// InstanceType is an entry in the instance type catalog
+type InstanceType struct {
+	Name         string
+	Category     string
+	VCPU         int
+	MemoryGiB    float64
+	Network      string
+	EbsOptimized string
+	Architecture string
+}
+
+// Family returns the instance family, "m4" for "m4.large"
+func (i InstanceType) Family() string {
+	if dot := strings.Index(i.Name, "."); dot > 0 {
+		return i.Name[:dot]
+	}
+	return i.Name
+}
+
+// Known reports if we have specs for the instance type, types that only came from the options endpoint don't
+func (i InstanceType) Known() bool {
+	return i.VCPU > 0
+}
+
+var (
+	InstanceCategories = []string{"General Purpose", "Compute Optimized", "Memory Optimized", "Storage Optimized", "Accelerated Computing"}
+	Architectures      = []string{"x86_64", "arm64"}
+
+	// instanceTypeCatalog is the bundled catalog, used offline and as the specs for types the options endpoint returns
+	instanceTypeCatalog = []InstanceType{
+		{"t2.nano", "General Purpose", 1, 0.5, "Low", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.micro", "General Purpose", 1, 1, "Low to Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.small", "General Purpose", 1, 2, "Low to Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.medium", "General Purpose", 2, 4, "Low to Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.large", "General Purpose", 2, 8, "Low to Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.xlarge", "General Purpose", 4, 16, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t2.2xlarge", "General Purpose", 8, 32, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"t3.nano", "General Purpose", 2, 0.5, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.micro", "General Purpose", 2, 1, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.small", "General Purpose", 2, 2, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.medium", "General Purpose", 2, 4, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.large", "General Purpose", 2, 8, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.xlarge", "General Purpose", 4, 16, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t3.2xlarge", "General Purpose", 8, 32, "Up to 5 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"t4g.nano", "General Purpose", 2, 0.5, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.micro", "General Purpose", 2, 1, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.small", "General Purpose", 2, 2, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.medium", "General Purpose", 2, 4, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.large", "General Purpose", 2, 8, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.xlarge", "General Purpose", 4, 16, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"t4g.2xlarge", "General Purpose", 8, 32, "Up to 5 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m3.medium", "General Purpose", 1, 3.75, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"m3.large", "General Purpose", 2, 7.5, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"m3.xlarge", "General Purpose", 4, 15, "High", EbsOptimizedSupported, "x86_64"},
+		{"m3.2xlarge", "General Purpose", 8, 30, "High", EbsOptimizedSupported, "x86_64"},
+		{"m4.large", "General Purpose", 2, 8, "Moderate", EbsOptimizedDefault, "x86_64"},
+		{"m4.xlarge", "General Purpose", 4, 16, "High", EbsOptimizedDefault, "x86_64"},
+		{"m4.2xlarge", "General Purpose", 8, 32, "High", EbsOptimizedDefault, "x86_64"},
+		{"m4.4xlarge", "General Purpose", 16, 64, "High", EbsOptimizedDefault, "x86_64"},
+		{"m4.10xlarge", "General Purpose", 40, 160, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m4.16xlarge", "General Purpose", 64, 256, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.large", "General Purpose", 2, 8, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.xlarge", "General Purpose", 4, 16, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.2xlarge", "General Purpose", 8, 32, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.4xlarge", "General Purpose", 16, 64, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.8xlarge", "General Purpose", 32, 128, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.12xlarge", "General Purpose", 48, 192, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.16xlarge", "General Purpose", 64, 256, "20 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m5.24xlarge", "General Purpose", 96, 384, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"m6g.medium", "General Purpose", 1, 4, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.large", "General Purpose", 2, 8, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.xlarge", "General Purpose", 4, 16, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.2xlarge", "General Purpose", 8, 32, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.4xlarge", "General Purpose", 16, 64, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.8xlarge", "General Purpose", 32, 128, "12 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.12xlarge", "General Purpose", 48, 192, "20 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"m6g.16xlarge", "General Purpose", 64, 256, "25 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"c3.large", "Compute Optimized", 2, 3.75, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"c3.xlarge", "Compute Optimized", 4, 7.5, "Moderate", EbsOptimizedSupported, "x86_64"},
+		{"c3.2xlarge", "Compute Optimized", 8, 15, "High", EbsOptimizedSupported, "x86_64"},
+		{"c3.4xlarge", "Compute Optimized", 16, 30, "High", EbsOptimizedSupported, "x86_64"},
+		{"c3.8xlarge", "Compute Optimized", 32, 60, "10 Gigabit", EbsOptimizedUnsupported, "x86_64"},
+		{"c4.large", "Compute Optimized", 2, 3.75, "Moderate", EbsOptimizedDefault, "x86_64"},
+		{"c4.xlarge", "Compute Optimized", 4, 7.5, "High", EbsOptimizedDefault, "x86_64"},
+		{"c4.2xlarge", "Compute Optimized", 8, 15, "High", EbsOptimizedDefault, "x86_64"},
+		{"c4.4xlarge", "Compute Optimized", 16, 30, "High", EbsOptimizedDefault, "x86_64"},
+		{"c4.8xlarge", "Compute Optimized", 36, 60, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.large", "Compute Optimized", 2, 4, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.xlarge", "Compute Optimized", 4, 8, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.2xlarge", "Compute Optimized", 8, 16, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.4xlarge", "Compute Optimized", 16, 32, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.9xlarge", "Compute Optimized", 36, 72, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c5.18xlarge", "Compute Optimized", 72, 144, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"c6g.medium", "Compute Optimized", 1, 2, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"c6g.large", "Compute Optimized", 2, 4, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"c6g.xlarge", "Compute Optimized", 4, 8, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"c6g.2xlarge", "Compute Optimized", 8, 16, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"c6g.4xlarge", "Compute Optimized", 16, 32, "Up to 10 Gigabit", EbsOptimizedDefault, "arm64"},
+		{"r3.large", "Memory Optimized", 2, 15.25, "Moderate", EbsOptimizedUnsupported, "x86_64"},
+		{"r3.xlarge", "Memory Optimized", 4, 30.5, "Moderate", EbsOptimizedSupported, "x86_64"},
+		{"r3.2xlarge", "Memory Optimized", 8, 61, "High", EbsOptimizedSupported, "x86_64"},
+		{"r3.4xlarge", "Memory Optimized", 16, 122, "High", EbsOptimizedSupported, "x86_64"},
+		{"r3.8xlarge", "Memory Optimized", 32, 244, "10 Gigabit", EbsOptimizedUnsupported, "x86_64"},
+		{"r4.large", "Memory Optimized", 2, 15.25, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r4.xlarge", "Memory Optimized", 4, 30.5, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r4.2xlarge", "Memory Optimized", 8, 61, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r4.4xlarge", "Memory Optimized", 16, 122, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r4.8xlarge", "Memory Optimized", 32, 244, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r4.16xlarge", "Memory Optimized", 64, 488, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.large", "Memory Optimized", 2, 16, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.xlarge", "Memory Optimized", 4, 32, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.2xlarge", "Memory Optimized", 8, 64, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.4xlarge", "Memory Optimized", 16, 128, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.8xlarge", "Memory Optimized", 32, 256, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.12xlarge", "Memory Optimized", 48, 384, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.16xlarge", "Memory Optimized", 64, 512, "20 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"r5.24xlarge", "Memory Optimized", 96, 768, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"x1.16xlarge", "Memory Optimized", 64, 976, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"x1.32xlarge", "Memory Optimized", 128, 1952, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i2.xlarge", "Storage Optimized", 4, 30.5, "Moderate", EbsOptimizedSupported, "x86_64"},
+		{"i2.2xlarge", "Storage Optimized", 8, 61, "High", EbsOptimizedSupported, "x86_64"},
+		{"i2.4xlarge", "Storage Optimized", 16, 122, "High", EbsOptimizedSupported, "x86_64"},
+		{"i2.8xlarge", "Storage Optimized", 32, 244, "10 Gigabit", EbsOptimizedUnsupported, "x86_64"},
+		{"i3.large", "Storage Optimized", 2, 15.25, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i3.xlarge", "Storage Optimized", 4, 30.5, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i3.2xlarge", "Storage Optimized", 8, 61, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i3.4xlarge", "Storage Optimized", 16, 122, "Up to 10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i3.8xlarge", "Storage Optimized", 32, 244, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"i3.16xlarge", "Storage Optimized", 64, 488, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"d2.xlarge", "Storage Optimized", 4, 30.5, "Moderate", EbsOptimizedDefault, "x86_64"},
+		{"d2.2xlarge", "Storage Optimized", 8, 61, "High", EbsOptimizedDefault, "x86_64"},
+		{"d2.4xlarge", "Storage Optimized", 16, 122, "High", EbsOptimizedDefault, "x86_64"},
+		{"d2.8xlarge", "Storage Optimized", 36, 244, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"p2.xlarge", "Accelerated Computing", 4, 61, "High", EbsOptimizedDefault, "x86_64"},
+		{"p2.8xlarge", "Accelerated Computing", 32, 488, "10 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"p2.16xlarge", "Accelerated Computing", 64, 732, "25 Gigabit", EbsOptimizedDefault, "x86_64"},
+		{"g2.2xlarge", "Accelerated Computing", 8, 15, "High", EbsOptimizedSupported, "x86_64"},
+		{"g2.8xlarge", "Accelerated Computing", 32, 60, "10 Gigabit", EbsOptimizedUnsupported, "x86_64"},
+	}
+)
+
+// InstanceTypeCatalog returns the bundled catalog, plus any instance types the options endpoint knows about that it
+// doesn't, sorted by family and then size
+func InstanceTypeCatalog(extraTypes []string) []InstanceType {
+	catalog := append([]InstanceType{}, instanceTypeCatalog...)
+
+	known := make(map[string]bool)
+	for _, instanceType := range catalog {
+		known[instanceType.Name] = true
+	}
+	for _, name := range extraTypes {
+		if !known[name] {
+			catalog = append(catalog, InstanceType{Name: name, Network: "unknown", EbsOptimized: EbsOptimizedSupported})
+			known[name] = true
+		}
+	}
+
+	sort.Stable(instanceTypes(catalog))
+	return catalog
+}
+
+// FindInstanceType looks up an instance type in a catalog
+func FindInstanceType(catalog []InstanceType, name string) (InstanceType, bool) {
+	for _, instanceType := range catalog {
+		if instanceType.Name == name {
+			return instanceType, true
+		}
+	}
+	return InstanceType{}, false
+}
+
+// NormalizeEbsOptimized returns the ebsOptimized setting a class can have with the instance type. Types that are always
+// EBS optimized, or never can be, only have the one setting
+func NormalizeEbsOptimized(instanceType string, ebsOptimized bool) bool {
+	if catalogType, ok := FindInstanceType(instanceTypeCatalog, instanceType); ok {
+		switch catalogType.EbsOptimized {
+		case EbsOptimizedUnsupported:
+			return false
+		case EbsOptimizedDefault:
+			return true
+		}
+	}
+	return ebsOptimized
+}
+
+type instanceTypes []InstanceType
+
+func (i instanceTypes) Len() int      { return len(i) }
+func (i instanceTypes) Swap(a, b int) { i[a], i[b] = i[b], i[a] }
+func (i instanceTypes) Less(a, b int) bool {
+	if i[a].Family() != i[b].Family() {
+		return i[a].Family() < i[b].Family()
+	}
+	if i[a].VCPU != i[b].VCPU {
+		return i[a].VCPU < i[b].VCPU
+	}
+	return i[a].MemoryGiB < i[b].MemoryGiB
+}
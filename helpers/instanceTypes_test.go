@@ -0,0 +1,26 @@
+package helpers
+
+import "testing"
+
+func TestNormalizeEbsOptimized(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		ebsOptimized bool
+		want         bool
+	}{
+		{instanceType: "m4.large", ebsOptimized: false, want: true},
+		{instanceType: "m5.xlarge", ebsOptimized: false, want: true},
+		{instanceType: "c4.large", ebsOptimized: true, want: true},
+		{instanceType: "t2.micro", ebsOptimized: true, want: false},
+		{instanceType: "m3.xlarge", ebsOptimized: true, want: true},
+		{instanceType: "m3.xlarge", ebsOptimized: false, want: false},
+		{instanceType: "z9.huge", ebsOptimized: true, want: true},
+		{instanceType: "", ebsOptimized: false, want: false},
+	}
+
+	for _, test := range tests {
+		if got := NormalizeEbsOptimized(test.instanceType, test.ebsOptimized); got != test.want {
+			t.Errorf("NormalizeEbsOptimized(%q, %t) = %t, want %t", test.instanceType, test.ebsOptimized, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var KeyPairTypes = []string{"rsa", "ed25519"}
+
+// KeyFingerprints are the fingerprints of a public key in the formats AWS shows
+type KeyFingerprints struct {
+	Type   string
+	MD5    string
+	SHA256 string
+}
+
+// ParsePublicKey parses an OpenSSH authorized_keys line or a PEM encoded public key
+func ParsePublicKey(key string) (ssh.PublicKey, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.New("Public Key is empty")
+	}
+
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		var pub interface{}
+		var err error
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("Unsupported PEM block in Public Key: %s", block.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid PEM Public Key: %s", err)
+		}
+
+		return ssh.NewPublicKey(pub)
+	}
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return nil, errors.New("Public Key is not in OpenSSH or PEM format")
+	}
+
+	return pub, nil
+}
+
+// ParsePrivateKey parses a PEM or OpenSSH private key
+func ParsePrivateKey(key string) (ssh.Signer, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, errors.New("Private Key is empty")
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(key))
+	if err != nil {
+		if _, ok := err.(*ssh.PassphraseMissingError); ok {
+			return nil, errors.New("Private Key is encrypted, please remove the passphrase")
+		}
+		return nil, errors.New("Private Key is not in OpenSSH or PEM format")
+	}
+
+	return signer, nil
+}
+
+// ValidateKeyPair makes sure the private key is the other half of the public key
+func ValidateKeyPair(publicKey, privateKey string) error {
+	pub, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	signer, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	if string(signer.PublicKey().Marshal()) != string(pub.Marshal()) {
+		return errors.New("Private Key does not match the Public Key")
+	}
+
+	return nil
+}
+
+// Fingerprints returns the MD5 fingerprint AWS shows for imported RSA keys, and the SHA256 fingerprint it shows for ED25519 keys
+func Fingerprints(pub ssh.PublicKey) KeyFingerprints {
+	sha := sha256.Sum256(pub.Marshal())
+	fingerprints := KeyFingerprints{
+		Type:   pub.Type(),
+		SHA256: base64.StdEncoding.EncodeToString(sha[:]),
+	}
+
+	// MD5 is only shown for RSA keys, and AWS hashes the DER encoded public key rather than the ssh wire format
+	if cryptoPub, ok := pub.(ssh.CryptoPublicKey); ok && pub.Type() == ssh.KeyAlgoRSA {
+		if der, err := x509.MarshalPKIXPublicKey(cryptoPub.CryptoPublicKey()); err == nil {
+			sum := md5.Sum(der)
+			hexSum := make([]string, len(sum))
+			for i, b := range sum {
+				hexSum[i] = fmt.Sprintf("%02x", b)
+			}
+			fingerprints.MD5 = strings.Join(hexSum, ":")
+		}
+	}
+
+	return fingerprints
+}
+
+// GenerateKeyPair creates a new key pair, returning the public key in OpenSSH format and the private key as PEM
+func GenerateKeyPair(keyType string) (string, string, error) {
+	var pub interface{}
+	var privBlock *pem.Block
+
+	switch keyType {
+	case "rsa":
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return "", "", err
+		}
+		pub = &priv.PublicKey
+		privBlock = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}
+
+	case "ed25519":
+		edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(edPriv)
+		if err != nil {
+			return "", "", err
+		}
+		pub = edPub
+		privBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+
+	default:
+		return "", "", errors.New("Unknown key type: " + keyType)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))), string(pem.EncodeToMemory(privBlock)), nil
+}
@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"net/url"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Navigate moves the router to path, which may carry a query string like "/instances?asset=i-1234"
+func Navigate(path string) {
+	js.Global.Get("ReactRouter").Get("browserHistory").Call("push", path)
+}
+
+// CurrentPath is the route being shown, without its query string
+func CurrentPath() string {
+	return js.Global.Get("location").Get("pathname").String()
+}
+
+// CurrentURL is the route being shown along with its query string
+func CurrentURL() string {
+	location := js.Global.Get("location")
+	return location.Get("pathname").String() + location.Get("search").String()
+}
+
+// QueryParam returns a value from the query string of the current route
+func QueryParam(key string) string {
+	values, err := url.ParseQuery(trimQuery(js.Global.Get("location").Get("search").String()))
+	if err != nil {
+		return ""
+	}
+	return values.Get(key)
+}
+
+func trimQuery(search string) string {
+	if len(search) > 0 && search[0] == '?' {
+		return search[1:]
+	}
+	return search
+}
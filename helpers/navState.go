@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"encoding/json"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+const collapsedNavGroupsKey = "awsmNavCollapsed"
+
+// LoadCollapsedNavGroups returns the nav groups collapsed in local storage
+func LoadCollapsedNavGroups() []string {
+	var groups []string
+
+	stored := js.Global.Get("localStorage").Call("getItem", collapsedNavGroupsKey)
+	if stored == nil || stored == js.Undefined {
+		return groups
+	}
+	json.Unmarshal([]byte(stored.String()), &groups)
+
+	return groups
+}
+
+// SaveCollapsedNavGroups stores the collapsed nav groups, so they stay collapsed between pages and visits
+func SaveCollapsedNavGroups(groups []string) {
+	data, _ := json.Marshal(groups)
+	js.Global.Get("localStorage").Call("setItem", collapsedNavGroupsKey, string(data))
+}
@@ -0,0 +1,174 @@
+package helpers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The bundled price tables are on demand linux prices from the AWS price list. Each row is an instance, volume or load
+// balancer type with a price for each region in the header, "-" where the type isn't offered
+
+// instancePriceTable is per instance hour
+const instancePriceTable = `
+type         us-east-1 us-east-2 us-west-1 us-west-2 ca-central-1 eu-west-1 eu-west-2 eu-central-1 ap-south-1 ap-northeast-1 ap-northeast-2 ap-southeast-1 ap-southeast-2 sa-east-1
+t2.nano      0.0058    0.0058    0.0069    0.0058    0.0064       0.0063    0.0066    0.0067       0.0062     0.0076         0.0072         0.0073         0.0073         0.0093
+t2.micro     0.0116    0.0116    0.0138    0.0116    0.0128       0.0126    0.0132    0.0134       0.0124     0.0152         0.0144         0.0146         0.0146         0.0186
+t2.small     0.023     0.023     0.0276    0.023     0.0256       0.0252    0.0264    0.0268       0.0248     0.0304         0.0288         0.0292         0.0292         0.0372
+t2.medium    0.0464    0.0464    0.0552    0.0464    0.0512       0.0504    0.0528    0.0536       0.0496     0.0608         0.0576         0.0584         0.0584         0.0744
+t2.large     0.0928    0.0928    0.1104    0.0928    0.1024       0.1008    0.1056    0.1072       0.0992     0.1216         0.1152         0.1168         0.1168         0.1488
+t2.xlarge    0.1856    0.1856    0.2208    0.1856    0.2048       0.2016    0.2112    0.2144       0.1984     0.2432         0.2304         0.2336         0.2336         0.2976
+t2.2xlarge   0.3712    0.3712    0.4416    0.3712    0.4096       0.4032    0.4224    0.4288       0.3968     0.4864         0.4608         0.4672         0.4672         0.5952
+t3.nano      0.0052    0.0052    0.0062    0.0052    0.0058       0.0057    0.0059    0.006        0.0056     0.0068         0.0065         0.0066         0.0066         0.0084
+t3.micro     0.0104    0.0104    0.0124    0.0104    0.0116       0.0114    0.0118    0.012        0.0112     0.0136         0.013          0.0132         0.0132         0.0168
+t3.small     0.0208    0.0208    0.0248    0.0208    0.0232       0.0228    0.0236    0.024        0.0224     0.0272         0.026          0.0264         0.0264         0.0336
+t3.medium    0.0416    0.0416    0.0496    0.0416    0.0464       0.0456    0.0472    0.048        0.0448     0.0544         0.052          0.0528         0.0528         0.0672
+t3.large     0.0832    0.0832    0.0992    0.0832    0.0928       0.0912    0.0944    0.096        0.0896     0.1088         0.104          0.1056         0.1056         0.1344
+t3.xlarge    0.1664    0.1664    0.1984    0.1664    0.1856       0.1824    0.1888    0.192        0.1792     0.2176         0.208          0.2112         0.2112         0.2688
+t3.2xlarge   0.3328    0.3328    0.3968    0.3328    0.3712       0.3648    0.3776    0.384        0.3584     0.4352         0.416          0.4224         0.4224         0.5376
+t4g.nano     0.0042    0.0042    0.005     0.0042    0.0046       0.0046    0.0047    0.0048       0.0028     0.0054         0.0052         0.0053         0.0053         0.0067
+t4g.micro    0.0084    0.0084    0.01      0.0084    0.0092       0.0092    0.0094    0.0096       0.0056     0.0108         0.0104         0.0106         0.0106         0.0134
+t4g.small    0.0168    0.0168    0.02      0.0168    0.0184       0.0184    0.0188    0.0192       0.0112     0.0216         0.0208         0.0212         0.0212         0.0268
+t4g.medium   0.0336    0.0336    0.04      0.0336    0.0368       0.0368    0.0376    0.0384       0.0224     0.0432         0.0416         0.0424         0.0424         0.0536
+t4g.large    0.0672    0.0672    0.08      0.0672    0.0736       0.0736    0.0752    0.0768       0.0448     0.0864         0.0832         0.0848         0.0848         0.1072
+t4g.xlarge   0.1344    0.1344    0.16      0.1344    0.1472       0.1472    0.1504    0.1536       0.0896     0.1728         0.1664         0.1696         0.1696         0.2144
+t4g.2xlarge  0.2688    0.2688    0.32      0.2688    0.2944       0.2944    0.3008    0.3072       0.1792     0.3456         0.3328         0.3392         0.3392         0.4288
+m3.medium    0.067     -         0.077     0.067     -            0.073     -         0.079        -          0.096          -              0.098          0.093          0.095
+m3.large     0.133     -         0.154     0.133     -            0.146     -         0.158        -          0.192          -              0.196          0.186          0.19
+m3.xlarge    0.266     -         0.308     0.266     -            0.292     -         0.316        -          0.384          -              0.392          0.372          0.38
+m3.2xlarge   0.532     -         0.616     0.532     -            0.584     -         0.632        -          0.768          -              0.784          0.744          0.76
+m4.large     0.1       0.1       0.117     0.1       0.111        0.111     0.116     0.12         0.105      0.129          0.123          0.125          0.125          0.159
+m4.xlarge    0.2       0.2       0.234     0.2       0.222        0.222     0.232     0.24         0.21       0.258          0.246          0.25           0.25           0.318
+m4.2xlarge   0.4       0.4       0.468     0.4       0.444        0.444     0.464     0.48         0.42       0.516          0.492          0.5            0.5            0.636
+m4.4xlarge   0.8       0.8       0.936     0.8       0.888        0.888     0.928     0.96         0.84       1.032          0.984          1              1              1.272
+m4.10xlarge  2         2         2.34      2         2.22         2.22      2.32      2.4          2.1        2.58           2.46           2.5            2.5            3.18
+m4.16xlarge  3.2       3.2       3.744     3.2       3.552        3.552     3.712     3.84         3.36       4.128          3.936          4              4              5.088
+m5.large     0.096     0.096     0.112     0.096     0.107        0.107     0.111     0.115        0.101      0.124          0.118          0.12           0.12           0.153
+m5.xlarge    0.192     0.192     0.224     0.192     0.214        0.214     0.222     0.23         0.202      0.248          0.236          0.24           0.24           0.306
+m5.2xlarge   0.384     0.384     0.448     0.384     0.428        0.428     0.444     0.46         0.404      0.496          0.472          0.48           0.48           0.612
+m5.4xlarge   0.768     0.768     0.896     0.768     0.856        0.856     0.888     0.92         0.808      0.992          0.944          0.96           0.96           1.224
+m5.8xlarge   1.536     1.536     1.792     1.536     1.712        1.712     1.776     1.84         1.616      1.984          1.888          1.92           1.92           2.448
+m5.12xlarge  2.304     2.304     2.688     2.304     2.568        2.568     2.664     2.76         2.424      2.976          2.832          2.88           2.88           3.672
+m5.16xlarge  3.072     3.072     3.584     3.072     3.424        3.424     3.552     3.68         3.232      3.968          3.776          3.84           3.84           4.896
+m5.24xlarge  4.608     4.608     5.376     4.608     5.136        5.136     5.328     5.52         4.848      5.952          5.664          5.76           5.76           7.344
+m6g.medium   0.0385    0.0385    0.0449    0.0385    0.0428       0.043     0.0444    0.046        0.0202     0.0497         0.0472         0.048          0.048          0.0612
+m6g.large    0.077     0.077     0.0898    0.077     0.0856       0.086     0.0888    0.092        0.0404     0.0994         0.0944         0.096          0.096          0.1224
+m6g.xlarge   0.154     0.154     0.1796    0.154     0.1712       0.172     0.1776    0.184        0.0808     0.1988         0.1888         0.192          0.192          0.2448
+m6g.2xlarge  0.308     0.308     0.3592    0.308     0.3424       0.344     0.3552    0.368        0.1616     0.3976         0.3776         0.384          0.384          0.4896
+m6g.4xlarge  0.616     0.616     0.7184    0.616     0.6848       0.688     0.7104    0.736        0.3232     0.7952         0.7552         0.768          0.768          0.9792
+m6g.8xlarge  1.232     1.232     1.4368    1.232     1.3696       1.376     1.4208    1.472        0.6464     1.5904         1.5104         1.536          1.536          1.9584
+m6g.12xlarge 1.848     1.848     2.1552    1.848     2.0544       2.064     2.1312    2.208        0.9696     2.3856         2.2656         2.304          2.304          2.9376
+m6g.16xlarge 2.464     2.464     2.8736    2.464     2.7392       2.752     2.8416    2.944        1.2928     3.1808         3.0208         3.072          3.072          3.9168
+c3.large     0.105     -         0.12      0.105     -            0.12      -         0.129        -          0.128          -              0.132          0.132          0.163
+c3.xlarge    0.21      -         0.24      0.21      -            0.24      -         0.258        -          0.256          -              0.264          0.264          0.326
+c3.2xlarge   0.42      -         0.48      0.42      -            0.48      -         0.516        -          0.512          -              0.528          0.528          0.652
+c3.4xlarge   0.84      -         0.96      0.84      -            0.96      -         1.032        -          1.024          -              1.056          1.056          1.304
+c3.8xlarge   1.68      -         1.92      1.68      -            1.92      -         2.064        -          2.048          -              2.112          2.112          2.608
+c4.large     0.1       0.1       0.124     0.1       0.11         0.113     0.119     0.114        0.1        0.126          0.114          0.115          0.13           0.155
+c4.xlarge    0.199     0.199     0.248     0.199     0.22         0.226     0.238     0.228        0.199      0.252          0.228          0.23           0.26           0.31
+c4.2xlarge   0.398     0.398     0.496     0.398     0.44         0.452     0.476     0.456        0.398      0.504          0.456          0.46           0.52           0.62
+c4.4xlarge   0.796     0.796     0.992     0.796     0.88         0.904     0.952     0.912        0.796      1.008          0.912          0.92           1.04           1.24
+c4.8xlarge   1.591     1.591     1.984     1.591     1.76         1.808     1.904     1.824        1.591      2.016          1.824          1.84           2.08           2.48
+c5.large     0.085     0.085     0.106     0.085     0.093        0.096     0.101     0.097        0.085      0.107          0.096          0.098          0.111          0.131
+c5.xlarge    0.17      0.17      0.212     0.17      0.186        0.192     0.202     0.194        0.17       0.214          0.192          0.196          0.222          0.262
+c5.2xlarge   0.34      0.34      0.424     0.34      0.372        0.384     0.404     0.388        0.34       0.428          0.384          0.392          0.444          0.524
+c5.4xlarge   0.68      0.68      0.848     0.68      0.744        0.768     0.808     0.776        0.68       0.856          0.768          0.784          0.888          1.048
+c5.9xlarge   1.53      1.53      1.908     1.53      1.674        1.728     1.818     1.746        1.53       1.926          1.728          1.764          1.998          2.358
+c5.18xlarge  3.06      3.06      3.816     3.06      3.348        3.456     3.636     3.492        3.06       3.852          3.456          3.528          3.996          4.716
+c6g.medium   0.034     0.034     0.0424    0.034     0.0372       0.0384    0.0404    0.0388       0.0172     0.0428         0.0384         0.0392         0.0444         0.0524
+c6g.large    0.068     0.068     0.0848    0.068     0.0744       0.0768    0.0808    0.0776       0.0344     0.0856         0.0768         0.0784         0.0888         0.1048
+c6g.xlarge   0.136     0.136     0.1696    0.136     0.1488       0.1536    0.1616    0.1552       0.0688     0.1712         0.1536         0.1568         0.1776         0.2096
+c6g.2xlarge  0.272     0.272     0.3392    0.272     0.2976       0.3072    0.3232    0.3104       0.1376     0.3424         0.3072         0.3136         0.3552         0.4192
+c6g.4xlarge  0.544     0.544     0.6784    0.544     0.5952       0.6144    0.6464    0.6208       0.2752     0.6848         0.6144         0.6272         0.7104         0.8384
+r3.large     0.166     -         0.185     0.166     -            0.185     -         0.2          0.19       0.2            0.2            0.2            0.2            0.35
+r3.xlarge    0.333     -         0.37      0.333     -            0.37      -         0.4          0.38       0.4            0.4            0.4            0.4            0.7
+r3.2xlarge   0.665     -         0.74      0.665     -            0.74      -         0.8          0.76       0.8            0.8            0.8            0.8            1.4
+r3.4xlarge   1.33      -         1.48      1.33      -            1.48      -         1.6          1.52       1.6            1.6            1.6            1.6            2.8
+r3.8xlarge   2.66      -         2.96      2.66      -            2.96      -         3.2          3.04       3.2            3.2            3.2            3.2            5.6
+r4.large     0.133     0.133     0.148     0.133     0.146        0.148     0.156     0.16         0.148      0.16           0.16           0.16           0.16           0.28
+r4.xlarge    0.266     0.266     0.296     0.266     0.292        0.296     0.312     0.32         0.296      0.32           0.32           0.32           0.32           0.56
+r4.2xlarge   0.532     0.532     0.592     0.532     0.584        0.592     0.624     0.64         0.592      0.64           0.64           0.64           0.64           1.12
+r4.4xlarge   1.064     1.064     1.184     1.064     1.168        1.184     1.248     1.28         1.184      1.28           1.28           1.28           1.28           2.24
+r4.8xlarge   2.128     2.128     2.368     2.128     2.336        2.368     2.496     2.56         2.368      2.56           2.56           2.56           2.56           4.48
+r4.16xlarge  4.256     4.256     4.736     4.256     4.672        4.736     4.992     5.12         4.736      5.12           5.12           5.12           5.12           8.96
+r5.large     0.126     0.126     0.14      0.126     0.138        0.141     0.148     0.152        0.127      0.152          0.152          0.152          0.151          0.2
+r5.xlarge    0.252     0.252     0.28      0.252     0.276        0.282     0.296     0.304        0.254      0.304          0.304          0.304          0.302          0.4
+r5.2xlarge   0.504     0.504     0.56      0.504     0.552        0.564     0.592     0.608        0.508      0.608          0.608          0.608          0.604          0.8
+r5.4xlarge   1.008     1.008     1.12      1.008     1.104        1.128     1.184     1.216        1.016      1.216          1.216          1.216          1.208          1.6
+r5.8xlarge   2.016     2.016     2.24      2.016     2.208        2.256     2.368     2.432        2.032      2.432          2.432          2.432          2.416          3.2
+r5.12xlarge  3.024     3.024     3.36      3.024     3.312        3.384     3.552     3.648        3.048      3.648          3.648          3.648          3.624          4.8
+r5.16xlarge  4.032     4.032     4.48      4.032     4.416        4.512     4.736     4.864        4.064      4.864          4.864          4.864          4.832          6.4
+r5.24xlarge  6.048     6.048     6.72      6.048     6.624        6.768     7.104     7.296        6.096      7.296          7.296          7.296          7.248          9.6
+x1.16xlarge  6.669     6.669     -         6.669     7.336        8.003     8.403     9.337        6.93       9.671          9.671          9.671          9.671          13.005
+x1.32xlarge  13.338    13.338    -         13.338    14.672       16.006    16.806    18.674       13.86      19.342         19.342         19.342         19.342         26.01
+i2.xlarge    0.853     -         0.938     0.853     -            0.938     -         1.013        0.967      1.001          1.001          1.018          1.018          -
+i2.2xlarge   1.705     -         1.876     1.705     -            1.876     -         2.026        1.934      2.002          2.002          2.036          2.036          -
+i2.4xlarge   3.41      -         3.752     3.41      -            3.752     -         4.052        3.868      4.004          4.004          4.072          4.072          -
+i2.8xlarge   6.82      -         7.504     6.82      -            7.504     -         8.104        7.736      8.008          8.008          8.144          8.144          -
+i3.large     0.156     0.156     0.172     0.156     0.172        0.172     0.181     0.186        0.178      0.183          0.183          0.187          0.187          0.286
+i3.xlarge    0.312     0.312     0.344     0.312     0.344        0.344     0.362     0.372        0.356      0.366          0.366          0.374          0.374          0.572
+i3.2xlarge   0.624     0.624     0.688     0.624     0.688        0.688     0.724     0.744        0.712      0.732          0.732          0.748          0.748          1.144
+i3.4xlarge   1.248     1.248     1.376     1.248     1.376        1.376     1.448     1.488        1.424      1.464          1.464          1.496          1.496          2.288
+i3.8xlarge   2.496     2.496     2.752     2.496     2.752        2.752     2.896     2.976        2.848      2.928          2.928          2.992          2.992          4.576
+i3.16xlarge  4.992     4.992     5.504     4.992     5.504        5.504     5.792     5.952        5.696      5.856          5.856          5.984          5.984          9.152
+d2.xlarge    0.69      0.69      0.781     0.69      0.759        0.735     0.772     0.794        0.827      0.844          0.844          0.87           0.87           -
+d2.2xlarge   1.38      1.38      1.562     1.38      1.518        1.47      1.544     1.588        1.654      1.688          1.688          1.74           1.74           -
+d2.4xlarge   2.76      2.76      3.124     2.76      3.036        2.94      3.088     3.176        3.308      3.376          3.376          3.48           3.48           -
+d2.8xlarge   5.52      5.52      6.248     5.52      6.072        5.88      6.176     6.352        6.616      6.752          6.752          6.96           6.96           -
+p2.xlarge    0.9       0.9       -         0.9       -            0.972     -         1.326        1.718      1.542          1.465          1.67           1.483          -
+p2.8xlarge   7.2       7.2       -         7.2       -            7.776     -         10.608       13.744     12.336         11.72          13.36          11.864         -
+p2.16xlarge  14.4      14.4      -         14.4      -            15.552    -         21.216       27.488     24.672         23.44          26.72          23.728         -
+g2.2xlarge   0.65      -         0.702     0.65      -            0.702     -         0.772        -          0.898          -              1              0.898          -
+g2.8xlarge   2.6       -         2.808     2.6       -            2.808     -         3.088        -          3.592          -              4              3.592          -
+`
+
+// volumePriceTable is per GB-month, and per provisioned IOPS-month for io1-iops
+const volumePriceTable = `
+type     us-east-1 us-east-2 us-west-1 us-west-2 ca-central-1 eu-west-1 eu-west-2 eu-central-1 ap-south-1 ap-northeast-1 ap-northeast-2 ap-southeast-1 ap-southeast-2 sa-east-1
+standard 0.05      0.05      0.08      0.05      0.055        0.055     0.058     0.059        0.08       0.08           0.08           0.08           0.08           0.12
+gp2      0.1       0.1       0.12      0.1       0.11         0.11      0.116     0.119        0.114      0.12           0.114          0.12           0.12           0.19
+io1      0.125     0.125     0.138     0.125     0.138        0.138     0.145     0.149        0.131      0.142          0.1278         0.138          0.138          0.238
+st1      0.045     0.045     0.054     0.045     0.05         0.05      0.053     0.054        0.051      0.054          0.051          0.054          0.054          0.086
+sc1      0.025     0.025     0.03      0.025     0.028        0.028     0.029     0.03         0.029      0.03           0.029          0.03           0.03           0.048
+io1-iops 0.065     0.065     0.072     0.065     0.072        0.072     0.076     0.078        0.068      0.074          0.0666         0.072          0.072          0.091
+`
+
+// loadBalancerPriceTable is per load balancer hour, not counting data processed
+const loadBalancerPriceTable = `
+type    us-east-1 us-east-2 us-west-1 us-west-2 ca-central-1 eu-west-1 eu-west-2 eu-central-1 ap-south-1 ap-northeast-1 ap-northeast-2 ap-southeast-1 ap-southeast-2 sa-east-1
+classic 0.025     0.025     0.028     0.025     0.0275       0.028     0.0294    0.03         0.0265     0.027          0.0252         0.028          0.028          0.034
+`
+
+var (
+	instanceHourlyPrices     = parsePriceTable(instancePriceTable)
+	volumeMonthlyPrices      = parsePriceTable(volumePriceTable)
+	loadBalancerHourlyPrices = parsePriceTable(loadBalancerPriceTable)
+)
+
+// parsePriceTable reads a price table into prices by type and then region
+func parsePriceTable(table string) map[string]map[string]float64 {
+	prices := make(map[string]map[string]float64)
+
+	var regions []string
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if regions == nil {
+			regions = fields[1:]
+			continue
+		}
+
+		prices[fields[0]] = make(map[string]float64)
+		for i, field := range fields[1:] {
+			if i >= len(regions) {
+				break
+			}
+			if price, err := strconv.ParseFloat(field, 64); err == nil {
+				prices[fields[0]][regions[i]] = price
+			}
+		}
+	}
+
+	return prices
+}
@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Jeffail/gabs"
+)
+
+// HoursPerMonth is what AWS uses to turn hourly prices into monthly ones
+const HoursPerMonth = 730
+
+var (
+	// CostRegions are the regions in the price tables, DefaultCostRegion is used for any other region
+	CostRegions       = []string{"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ca-central-1", "eu-west-1", "eu-west-2", "eu-central-1", "ap-south-1", "ap-northeast-1", "ap-northeast-2", "ap-southeast-1", "ap-southeast-2", "sa-east-1"}
+	DefaultCostRegion = "us-east-1"
+)
+
+// regionPrice looks up the price of a type in a price table, false if the type isn't offered in the region
+func regionPrice(prices map[string]map[string]float64, priceType, region string) (float64, bool) {
+	if !containsCostRegion(region) {
+		region = DefaultCostRegion
+	}
+	price, ok := prices[priceType][region]
+	return price, ok
+}
+
+func containsCostRegion(region string) bool {
+	for _, r := range CostRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// InstanceMonthlyCost estimates the monthly on demand cost of an instance type
+func InstanceMonthlyCost(instanceType, region string) (float64, bool) {
+	hourly, ok := regionPrice(instanceHourlyPrices, instanceType, region)
+	if !ok {
+		return 0, false
+	}
+	return hourly * HoursPerMonth, true
+}
+
+// VolumeMonthlyCost estimates the monthly cost of an EBS volume
+func VolumeMonthlyCost(volumeType string, size, iops int, region string) (float64, bool) {
+	perGB, ok := regionPrice(volumeMonthlyPrices, volumeType, region)
+	if !ok {
+		return 0, false
+	}
+
+	cost := perGB * float64(size)
+	if volumeType == "io1" {
+		perIops, _ := regionPrice(volumeMonthlyPrices, "io1-iops", region)
+		cost += perIops * float64(iops)
+	}
+
+	return cost, true
+}
+
+// LoadBalancerMonthlyCost estimates the monthly cost of a classic load balancer, not counting data processed
+func LoadBalancerMonthlyCost(region string) float64 {
+	hourly, _ := regionPrice(loadBalancerHourlyPrices, "classic", region)
+	return hourly * HoursPerMonth
+}
+
+// HasAssetCost reports if we have prices for an asset type
+func HasAssetCost(assetType string) bool {
+	switch assetType {
+	case "instances", "volumes", "loadbalancers":
+		return true
+	}
+	return false
+}
+
+// AssetMonthlyCost estimates the monthly cost of a live asset from an asset list, if it's a type we have prices for
+func AssetMonthlyCost(assetType string, asset *gabs.Container) (float64, bool) {
+	region, _ := asset.S("region").Data().(string)
+
+	switch assetType {
+	case "instances":
+		if state, _ := asset.S("state").Data().(string); state == "stopped" || state == "terminated" {
+			return 0, true
+		}
+		instanceType, _ := asset.S("instanceType").Data().(string)
+		return InstanceMonthlyCost(instanceType, region)
+
+	case "volumes":
+		volumeType, _ := asset.S("volumeType").Data().(string)
+		size := assetInt(asset, "size", "volumeSize")
+		return VolumeMonthlyCost(volumeType, size, assetInt(asset, "iops"), region)
+
+	case "loadbalancers":
+		return LoadBalancerMonthlyCost(region), true
+	}
+
+	return 0, false
+}
+
+// FormatCost formats a monthly cost in dollars
+func FormatCost(monthly float64) string {
+	return fmt.Sprintf("$%.2f/mo", monthly)
+}
+
+// assetInt reads the first of the keys that holds a number, asset lists use both numbers and strings
+func assetInt(asset *gabs.Container, keys ...string) int {
+	for _, key := range keys {
+		switch value := asset.S(key).Data().(type) {
+		case float64:
+			return int(value)
+		case string:
+			if i, err := strconv.Atoi(value); err == nil {
+				return i
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPriceTables(t *testing.T) {
+	for name, table := range map[string]string{"instance": instancePriceTable, "volume": volumePriceTable, "load balancer": loadBalancerPriceTable} {
+		lines := strings.Split(strings.TrimSpace(table), "\n")
+		if header := strings.Fields(lines[0])[1:]; !reflect.DeepEqual(header, CostRegions) {
+			t.Errorf("%s price table regions = %v, want CostRegions %v", name, header, CostRegions)
+		}
+		for _, line := range lines[1:] {
+			if fields := strings.Fields(line); len(fields) != len(CostRegions)+1 {
+				t.Errorf("%s price table row %q has %d prices, want %d", name, fields[0], len(fields)-1, len(CostRegions))
+			}
+		}
+	}
+}
+
+func TestInstanceMonthlyCost(t *testing.T) {
+	tests := []struct {
+		instanceType string
+		region       string
+		want         float64
+		ok           bool
+	}{
+		{instanceType: "m4.large", region: "us-east-1", want: 73, ok: true},
+		{instanceType: "m4.large", region: "eu-central-1", want: 87.6, ok: true},
+		{instanceType: "m4.large", region: "", want: 73, ok: true},
+		{instanceType: "m4.large", region: "mars-north-1", want: 73, ok: true},
+		{instanceType: "t2.micro", region: "sa-east-1", want: 13.578, ok: true},
+		{instanceType: "m3.medium", region: "us-east-2", ok: false},
+		{instanceType: "z9.huge", region: "us-east-1", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := InstanceMonthlyCost(test.instanceType, test.region)
+		if ok != test.ok || !closeTo(got, test.want) {
+			t.Errorf("InstanceMonthlyCost(%s, %q) = %v, %t, want %v, %t", test.instanceType, test.region, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestVolumeMonthlyCost(t *testing.T) {
+	tests := []struct {
+		volumeType string
+		size, iops int
+		region     string
+		want       float64
+		ok         bool
+	}{
+		{volumeType: "gp2", size: 100, region: "us-east-1", want: 10, ok: true},
+		{volumeType: "gp2", size: 100, region: "sa-east-1", want: 19, ok: true},
+		{volumeType: "io1", size: 100, iops: 1000, region: "us-east-1", want: 77.5, ok: true},
+		{volumeType: "io1", size: 100, iops: 1000, region: "eu-west-1", want: 85.8, ok: true},
+		{volumeType: "gp2", size: 0, region: "us-east-1", want: 0, ok: true},
+		{volumeType: "gp9", size: 100, region: "us-east-1", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := VolumeMonthlyCost(test.volumeType, test.size, test.iops, test.region)
+		if ok != test.ok || !closeTo(got, test.want) {
+			t.Errorf("VolumeMonthlyCost(%s, %d, %d, %q) = %v, %t, want %v, %t", test.volumeType, test.size, test.iops, test.region, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestLoadBalancerMonthlyCost(t *testing.T) {
+	for region, want := range map[string]float64{"us-east-1": 18.25, "ap-northeast-1": 19.71, "unknown": 18.25} {
+		if got := LoadBalancerMonthlyCost(region); !closeTo(got, want) {
+			t.Errorf("LoadBalancerMonthlyCost(%q) = %v, want %v", region, got, want)
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return a-b < 0.0001 && b-a < 0.0001
+}
+
+func TestCatalogPrices(t *testing.T) {
+	for _, instanceType := range InstanceTypeCatalog(nil) {
+		if _, ok := InstanceMonthlyCost(instanceType.Name, DefaultCostRegion); !ok {
+			t.Errorf("catalog type %s has no %s price", instanceType.Name, DefaultCostRegion)
+		}
+	}
+}
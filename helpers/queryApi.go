@@ -3,15 +3,38 @@ package helpers
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	"github.com/gopherjs/gopherjs/js"
 	"golang.org/x/net/context/ctxhttp"
 )
 
+// RequestIDHeader carries a unique id on every API call, so audit log entries can be matched to dashboard requests
+const RequestIDHeader = "X-Request-ID"
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// prepareRequest sets the headers every API call carries
+func prepareRequest(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	if id := newRequestID(); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	authorize(req)
+}
+
 func GetAPI(url string) ([]byte, error) {
 	println("Getting from: " + url)
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
@@ -21,12 +44,16 @@ func GetAPI(url string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	prepareRequest(req)
 
 	resp, err := ctxhttp.Do(ctx, nil, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkAuthorized(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
 
 	defer resp.Body.Close()
 
@@ -37,6 +64,32 @@ func GetAPI(url string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+// GetPublic fetches a third party url with a bare XMLHttpRequest. No headers are added, so the browser sends it as a
+// simple request without a CORS preflight, and the session token stays with the awsm API
+func GetPublic(url string) ([]byte, error) {
+	println("Getting from: " + url)
+	done := make(chan error, 1)
+
+	xhr := js.Global.Get("XMLHttpRequest").New()
+	xhr.Call("open", "GET", url, true)
+	xhr.Set("timeout", 6000)
+	xhr.Set("onload", func() {
+		if xhr.Get("status").Int() != 200 {
+			done <- errors.New("Error fetching data!")
+			return
+		}
+		done <- nil
+	})
+	xhr.Set("onerror", func() { done <- errors.New("Error fetching data!") })
+	xhr.Set("ontimeout", func() { done <- errors.New("Error fetching data!") })
+	xhr.Call("send")
+
+	if err := <-done; err != nil {
+		return nil, err
+	}
+	return []byte(xhr.Get("responseText").String()), nil
+}
+
 func PutAPI(url string, data map[string]interface{}) ([]byte, error) {
 	println("Posting to: " + url)
 	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
@@ -51,12 +104,16 @@ func PutAPI(url string, data map[string]interface{}) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	prepareRequest(req)
 
 	resp, err := ctxhttp.Do(ctx, nil, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkAuthorized(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	return ioutil.ReadAll(resp.Body)
@@ -71,13 +128,50 @@ func DeleteAPI(url string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	prepareRequest(req)
 
 	resp, err := ctxhttp.Do(ctx, nil, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkAuthorized(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	return ioutil.ReadAll(resp.Body)
 }
+
+func PostAPI(url string, data map[string]interface{}) ([]byte, error) {
+	println("Posting to: " + url)
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, buf)
+	if err != nil {
+		return nil, err
+	}
+	prepareRequest(req)
+
+	resp, err := ctxhttp.Do(ctx, nil, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAuthorized(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New("Error posting data!")
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RotationAsset is a versioned asset created from a class, like a snapshot or image
+type RotationAsset struct {
+	ID      string
+	Name    string
+	Region  string
+	Created string
+	Version int
+}
+
+// RotationPlan is what a rotation would do in one region
+type RotationPlan struct {
+	Region    string
+	Keep      []RotationAsset
+	Prune     []RotationAsset
+	NextPrune *RotationAsset // pruned once the next version is created
+}
+
+type rotationAssets []RotationAsset
+
+func (r rotationAssets) Len() int      { return len(r) }
+func (r rotationAssets) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r rotationAssets) Less(i, j int) bool {
+	if r[i].Version != r[j].Version {
+		return r[i].Version > r[j].Version
+	}
+	return r[i].Created > r[j].Created
+}
+
+// AssetNameVersion reads the version out of an awsm asset name, "class-v3"
+func AssetNameVersion(name string) int {
+	i := strings.LastIndex(name, "-v")
+	if i < 0 {
+		return 0
+	}
+	version, _ := strconv.Atoi(name[i+2:])
+	return version
+}
+
+// PlanRotation works out which assets each region keeps and prunes, newest first, under the retain count
+func PlanRotation(assets []RotationAsset, retain int) []RotationPlan {
+	byRegion := make(map[string][]RotationAsset)
+	for _, asset := range assets {
+		byRegion[asset.Region] = append(byRegion[asset.Region], asset)
+	}
+
+	var regions []string
+	for region := range byRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	plans := make([]RotationPlan, len(regions))
+	for i, region := range regions {
+		regionAssets := byRegion[region]
+		sort.Sort(rotationAssets(regionAssets))
+
+		plan := RotationPlan{Region: region, Keep: regionAssets}
+		if retain > 0 && len(regionAssets) > retain {
+			plan.Keep, plan.Prune = regionAssets[:retain], regionAssets[retain:]
+		}
+		if retain > 0 && len(plan.Keep) == retain {
+			plan.NextPrune = &plan.Keep[len(plan.Keep)-1]
+		}
+
+		plans[i] = plan
+	}
+
+	return plans
+}
+
+// LatestVersions returns the newest version found in each region
+func LatestVersions(assets []RotationAsset) map[string]int {
+	latest := make(map[string]int)
+	for _, asset := range assets {
+		if asset.Version > latest[asset.Region] {
+			latest[asset.Region] = asset.Version
+		}
+	}
+	return latest
+}
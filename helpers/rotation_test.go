@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssetNameVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+	}{
+		{name: "web-v3", want: 3},
+		{name: "web-server-v12", want: 12},
+		{name: "web-v1-v2", want: 2},
+		{name: "web", want: 0},
+		{name: "vpc-web", want: 0},
+		{name: "web-v", want: 0},
+		{name: "web-v3-copy", want: 0},
+	}
+
+	for _, test := range tests {
+		if got := AssetNameVersion(test.name); got != test.want {
+			t.Errorf("AssetNameVersion(%q) = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func rotationNames(assets []RotationAsset) []string {
+	var names []string
+	for _, asset := range assets {
+		names = append(names, asset.Name)
+	}
+	return names
+}
+
+func TestPlanRotation(t *testing.T) {
+	assets := []RotationAsset{
+		{Name: "web-v1", Region: "us-east-1", Version: 1},
+		{Name: "web-v3", Region: "us-east-1", Version: 3},
+		{Name: "web-v2", Region: "us-east-1", Version: 2},
+		{Name: "web-v2b", Region: "us-east-1", Version: 2, Created: "2017-01-02"},
+		{Name: "web-v1", Region: "us-west-2", Version: 1},
+	}
+
+	tests := []struct {
+		retain    int
+		region    string
+		keep      []string
+		prune     []string
+		nextPrune string
+	}{
+		{retain: 0, region: "us-east-1", keep: []string{"web-v3", "web-v2b", "web-v2", "web-v1"}},
+		{retain: 2, region: "us-east-1", keep: []string{"web-v3", "web-v2b"}, prune: []string{"web-v2", "web-v1"}, nextPrune: "web-v2b"},
+		{retain: 4, region: "us-east-1", keep: []string{"web-v3", "web-v2b", "web-v2", "web-v1"}, nextPrune: "web-v1"},
+		{retain: 5, region: "us-east-1", keep: []string{"web-v3", "web-v2b", "web-v2", "web-v1"}},
+		{retain: 1, region: "us-west-2", keep: []string{"web-v1"}, nextPrune: "web-v1"},
+	}
+
+	for _, test := range tests {
+		plans := PlanRotation(append([]RotationAsset{}, assets...), test.retain)
+		if len(plans) != 2 || plans[0].Region != "us-east-1" || plans[1].Region != "us-west-2" {
+			t.Fatalf("PlanRotation(retain %d) returned regions %+v, want us-east-1 and us-west-2", test.retain, plans)
+		}
+
+		var plan RotationPlan
+		for _, p := range plans {
+			if p.Region == test.region {
+				plan = p
+			}
+		}
+
+		if got := rotationNames(plan.Keep); !reflect.DeepEqual(got, test.keep) {
+			t.Errorf("PlanRotation(retain %d) keeps %v in %s, want %v", test.retain, got, test.region, test.keep)
+		}
+		if got := rotationNames(plan.Prune); !reflect.DeepEqual(got, test.prune) {
+			t.Errorf("PlanRotation(retain %d) prunes %v in %s, want %v", test.retain, got, test.region, test.prune)
+		}
+
+		var nextPrune string
+		if plan.NextPrune != nil {
+			nextPrune = plan.NextPrune.Name
+		}
+		if nextPrune != test.nextPrune {
+			t.Errorf("PlanRotation(retain %d) next prunes %q in %s, want %q", test.retain, nextPrune, test.region, test.nextPrune)
+		}
+	}
+}
+
+func TestLatestVersions(t *testing.T) {
+	assets := []RotationAsset{
+		{Region: "us-east-1", Version: 2},
+		{Region: "us-east-1", Version: 5},
+		{Region: "us-west-2", Version: 1},
+		{Region: "eu-west-1"},
+	}
+
+	want := map[string]int{"us-east-1": 5, "us-west-2": 1}
+	if got := LatestVersions(assets); !reflect.DeepEqual(got, want) {
+		t.Errorf("LatestVersions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,252 @@
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxSimulationMinutes is the longest the simulator runs, a day
+const MaxSimulationMinutes = 24 * 60
+
+// MetricShapes are the synthetic metric curves the simulator can generate
+var MetricShapes = []string{"ramp", "spike", "step", "sine", "constant"}
+
+// SimGroup is the capacity settings of an autoscale group class
+type SimGroup struct {
+	MinSize         int
+	MaxSize         int
+	DesiredCapacity int
+	DefaultCooldown int
+}
+
+// SimAlarm is an alarm class watching the simulated metric
+type SimAlarm struct {
+	Name               string
+	Threshold          float64
+	ComparisonOperator string
+	Period             int
+	EvaluationPeriods  int
+	AlarmActions       []string
+	OKActions          []string
+}
+
+// SimPolicy is a simple scaling policy class
+type SimPolicy struct {
+	Name              string
+	AdjustmentType    string
+	ScalingAdjustment int
+	Cooldown          int
+}
+
+// SimStep is the state of the simulation at the end of one minute
+type SimStep struct {
+	Minute      int
+	Metric      float64
+	Capacity    int
+	AlarmStates map[string]string
+	Events      []string
+}
+
+// MetricCurve generates one metric sample per minute
+func MetricCurve(shape string, base, peak float64, minutes int) []float64 {
+	if minutes < 0 {
+		minutes = 0
+	} else if minutes > MaxSimulationMinutes {
+		minutes = MaxSimulationMinutes
+	}
+	curve := make([]float64, minutes)
+
+	for i := range curve {
+		progress := float64(i) / math.Max(float64(minutes-1), 1)
+
+		switch shape {
+		case "ramp":
+			// up to the peak at the half way point and back down
+			curve[i] = base + (peak-base)*(1-math.Abs(2*progress-1))
+		case "spike":
+			curve[i] = base
+			if progress >= 0.25 && progress < 0.4 {
+				curve[i] = peak
+			}
+		case "step":
+			curve[i] = base
+			if progress >= 0.25 {
+				curve[i] = peak
+			}
+		case "sine":
+			curve[i] = base + (peak-base)*(1-math.Cos(2*math.Pi*progress))/2
+		default:
+			curve[i] = base
+		}
+	}
+
+	return curve
+}
+
+// ParseMetricCurve reads a comma or space separated list of per minute samples
+func ParseMetricCurve(values string) ([]float64, error) {
+	var curve []float64
+
+	for _, field := range strings.FieldsFunc(values, func(r rune) bool { return r == ',' || r == ' ' || r == '\n' }) {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", field)
+		}
+		curve = append(curve, value)
+	}
+	if len(curve) > MaxSimulationMinutes {
+		return nil, fmt.Errorf("Custom Curve has %d values, the most the simulator runs is %d minutes", len(curve), MaxSimulationMinutes)
+	}
+
+	return curve, nil
+}
+
+// ScalingAdjustment returns the new capacity after applying a policy, before min/max clamping
+func ScalingAdjustment(capacity int, policy SimPolicy) int {
+	switch policy.AdjustmentType {
+	case "ExactCapacity":
+		return policy.ScalingAdjustment
+
+	case "PercentChangeInCapacity":
+		// AWS rounds toward zero, but always changes by at least one instance
+		change := float64(capacity) * float64(policy.ScalingAdjustment) / 100
+		switch {
+		case change > 0 && change < 1:
+			return capacity + 1
+		case change < 0 && change > -1:
+			return capacity - 1
+		}
+		return capacity + int(change)
+
+	default: // ChangeInCapacity
+		return capacity + policy.ScalingAdjustment
+	}
+}
+
+// SimulateScaling steps through a metric curve one minute at a time, evaluating alarms at the end of each of their
+// periods and running scaling policies while alarms are in the ALARM state, honoring cooldowns and min/max size
+func SimulateScaling(group SimGroup, alarms []SimAlarm, policies map[string]SimPolicy, curve []float64) []SimStep {
+	steps := make([]SimStep, len(curve))
+
+	capacity := clampCapacity(group.DesiredCapacity, group)
+	cooldownUntil := 0
+
+	alarmStates := make(map[string]string)
+	breaches := make(map[string][]bool)
+	for _, alarm := range alarms {
+		alarmStates[alarm.Name] = "INSUFFICIENT_DATA"
+	}
+
+	for minute, metric := range curve {
+		step := SimStep{Minute: minute + 1, Metric: metric}
+
+		for _, alarm := range alarms {
+			periodMinutes := alarm.Period / 60
+			if periodMinutes < 1 {
+				periodMinutes = 1
+			}
+			if (minute+1)%periodMinutes != 0 {
+				continue
+			}
+
+			// Average the samples over the period
+			sum := 0.0
+			for _, m := range curve[minute+1-periodMinutes : minute+1] {
+				sum += m
+			}
+			breaches[alarm.Name] = append(breaches[alarm.Name], compareThreshold(sum/float64(periodMinutes), alarm))
+
+			evaluationPeriods := alarm.EvaluationPeriods
+			if evaluationPeriods < 1 {
+				evaluationPeriods = 1
+			}
+			history := breaches[alarm.Name]
+			if len(history) < evaluationPeriods {
+				continue
+			}
+
+			state := "ALARM"
+			for _, breached := range history[len(history)-evaluationPeriods:] {
+				if !breached {
+					state = "OK"
+					break
+				}
+			}
+
+			changed := state != alarmStates[alarm.Name]
+			if changed {
+				step.Events = append(step.Events, fmt.Sprintf("%s: %s -> %s", alarm.Name, alarmStates[alarm.Name], state))
+				alarmStates[alarm.Name] = state
+			}
+
+			// Auto Scaling keeps running the actions every period while the alarm stays in that state
+			actions := alarm.OKActions
+			if state == "ALARM" {
+				actions = alarm.AlarmActions
+			}
+
+			for _, name := range actions {
+				policy, ok := policies[name]
+				if !ok {
+					continue
+				}
+				if minute < cooldownUntil {
+					step.Events = append(step.Events, fmt.Sprintf("%s skipped, cooling down until minute %d", name, cooldownUntil+1))
+					continue
+				}
+
+				newCapacity := clampCapacity(ScalingAdjustment(capacity, policy), group)
+				if newCapacity == capacity {
+					if changed {
+						step.Events = append(step.Events, fmt.Sprintf("%s had no effect, capacity is at %d", name, capacity))
+					}
+					continue
+				}
+
+				step.Events = append(step.Events, fmt.Sprintf("%s: capacity %d -> %d", name, capacity, newCapacity))
+				capacity = newCapacity
+
+				cooldown := policy.Cooldown
+				if cooldown <= 0 {
+					cooldown = group.DefaultCooldown
+				}
+				cooldownUntil = minute + int(math.Ceil(float64(cooldown)/60))
+			}
+		}
+
+		step.Capacity = capacity
+		step.AlarmStates = make(map[string]string)
+		for name, state := range alarmStates {
+			step.AlarmStates[name] = state
+		}
+		steps[minute] = step
+	}
+
+	return steps
+}
+
+func compareThreshold(value float64, alarm SimAlarm) bool {
+	switch alarm.ComparisonOperator {
+	case "GreaterThanOrEqualToThreshold":
+		return value >= alarm.Threshold
+	case "GreaterThanThreshold":
+		return value > alarm.Threshold
+	case "LessThanThreshold":
+		return value < alarm.Threshold
+	case "LessThanOrEqualToThreshold":
+		return value <= alarm.Threshold
+	}
+	return false
+}
+
+func clampCapacity(capacity int, group SimGroup) int {
+	if capacity < group.MinSize {
+		capacity = group.MinSize
+	}
+	if group.MaxSize > 0 && capacity > group.MaxSize {
+		capacity = group.MaxSize
+	}
+	return capacity
+}
@@ -0,0 +1,198 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMetricCurve(t *testing.T) {
+	tests := []struct {
+		shape string
+		want  []float64
+	}{
+		{shape: "constant", want: []float64{10, 10, 10, 10, 10}},
+		{shape: "unknown", want: []float64{10, 10, 10, 10, 10}},
+		{shape: "ramp", want: []float64{10, 55, 100, 55, 10}},
+		{shape: "step", want: []float64{10, 100, 100, 100, 100}},
+		{shape: "spike", want: []float64{10, 100, 10, 10, 10}},
+		{shape: "sine", want: []float64{10, 55, 100, 55, 10}},
+	}
+
+	for _, test := range tests {
+		got := MetricCurve(test.shape, 10, 100, 5)
+		for i := range got {
+			// sine lands a hair off the exact values
+			if diff := got[i] - test.want[i]; diff > 0.0001 || diff < -0.0001 {
+				t.Errorf("MetricCurve(%q) = %v, want %v", test.shape, got, test.want)
+				break
+			}
+		}
+	}
+
+	if got := MetricCurve("ramp", 10, 100, 1); !reflect.DeepEqual(got, []float64{10}) {
+		t.Errorf("MetricCurve(ramp) with one minute = %v, want [10]", got)
+	}
+
+	// out of range minutes are clamped rather than allocated
+	lengths := []struct {
+		minutes int
+		want    int
+	}{
+		{minutes: -5, want: 0},
+		{minutes: 0, want: 0},
+		{minutes: MaxSimulationMinutes, want: MaxSimulationMinutes},
+		{minutes: 1 << 40, want: MaxSimulationMinutes},
+	}
+	for _, test := range lengths {
+		if got := MetricCurve("ramp", 10, 100, test.minutes); len(got) != test.want {
+			t.Errorf("MetricCurve(ramp) with %d minutes has %d samples, want %d", test.minutes, len(got), test.want)
+		}
+	}
+}
+
+func TestParseMetricCurve(t *testing.T) {
+	tests := []struct {
+		values string
+		want   []float64
+		errors bool
+	}{
+		{values: "", want: nil},
+		{values: "1,2,3", want: []float64{1, 2, 3}},
+		{values: "1, 2.5\n3  4", want: []float64{1, 2.5, 3, 4}},
+		{values: "1,,2", want: []float64{1, 2}},
+		{values: "1,two,3", errors: true},
+		{values: strings.Repeat("1,", MaxSimulationMinutes), want: MetricCurve("constant", 1, 1, MaxSimulationMinutes)},
+		{values: strings.Repeat("1,", MaxSimulationMinutes+1), errors: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseMetricCurve(test.values)
+		if (err != nil) != test.errors {
+			t.Errorf("ParseMetricCurve(%q) error = %v, want error %t", test.values, err, test.errors)
+			continue
+		}
+		if !test.errors && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseMetricCurve(%q) = %v, want %v", test.values, got, test.want)
+		}
+	}
+}
+
+func TestScalingAdjustment(t *testing.T) {
+	tests := []struct {
+		capacity       int
+		adjustmentType string
+		adjustment     int
+		want           int
+	}{
+		{capacity: 4, adjustmentType: "ChangeInCapacity", adjustment: 2, want: 6},
+		{capacity: 4, adjustmentType: "ChangeInCapacity", adjustment: -3, want: 1},
+		{capacity: 4, adjustmentType: "", adjustment: 1, want: 5},
+		{capacity: 4, adjustmentType: "ExactCapacity", adjustment: 10, want: 10},
+		{capacity: 10, adjustmentType: "PercentChangeInCapacity", adjustment: 50, want: 15},
+		{capacity: 10, adjustmentType: "PercentChangeInCapacity", adjustment: 25, want: 12},
+		{capacity: 10, adjustmentType: "PercentChangeInCapacity", adjustment: -25, want: 8},
+		{capacity: 2, adjustmentType: "PercentChangeInCapacity", adjustment: 10, want: 3},
+		{capacity: 2, adjustmentType: "PercentChangeInCapacity", adjustment: -10, want: 1},
+		{capacity: 0, adjustmentType: "PercentChangeInCapacity", adjustment: 50, want: 0},
+	}
+
+	for _, test := range tests {
+		policy := SimPolicy{AdjustmentType: test.adjustmentType, ScalingAdjustment: test.adjustment}
+		if got := ScalingAdjustment(test.capacity, policy); got != test.want {
+			t.Errorf("ScalingAdjustment(%d, %s %d) = %d, want %d", test.capacity, test.adjustmentType, test.adjustment, got, test.want)
+		}
+	}
+}
+
+func TestSimulateScaling(t *testing.T) {
+	curve := []float64{10, 10, 90, 90, 90, 90, 90, 90, 90, 90}
+	alarm := SimAlarm{
+		Name:               "cpu-high",
+		Threshold:          70,
+		ComparisonOperator: "GreaterThanThreshold",
+		Period:             60,
+		EvaluationPeriods:  2,
+		AlarmActions:       []string{"scale-up"},
+	}
+
+	tests := []struct {
+		name       string
+		group      SimGroup
+		policy     SimPolicy
+		alarm      SimAlarm
+		want       []int
+		finalState string
+	}{
+		{
+			name:       "cooldown",
+			group:      SimGroup{MinSize: 1, MaxSize: 5, DesiredCapacity: 1},
+			policy:     SimPolicy{Name: "scale-up", ScalingAdjustment: 1, Cooldown: 300},
+			alarm:      alarm,
+			want:       []int{1, 1, 1, 2, 2, 2, 2, 2, 3, 3},
+			finalState: "ALARM",
+		},
+		{
+			name:       "default cooldown",
+			group:      SimGroup{MinSize: 1, MaxSize: 5, DesiredCapacity: 1, DefaultCooldown: 120},
+			policy:     SimPolicy{Name: "scale-up", ScalingAdjustment: 1},
+			alarm:      alarm,
+			want:       []int{1, 1, 1, 2, 2, 3, 3, 4, 4, 5},
+			finalState: "ALARM",
+		},
+		{
+			name:       "max size",
+			group:      SimGroup{MinSize: 1, MaxSize: 2, DesiredCapacity: 1},
+			policy:     SimPolicy{Name: "scale-up", ScalingAdjustment: 1, Cooldown: 60},
+			alarm:      alarm,
+			want:       []int{1, 1, 1, 2, 2, 2, 2, 2, 2, 2},
+			finalState: "ALARM",
+		},
+		{
+			name:       "desired below min",
+			group:      SimGroup{MinSize: 2, MaxSize: 5, DesiredCapacity: 0},
+			policy:     SimPolicy{Name: "scale-up", ScalingAdjustment: 1, Cooldown: 600},
+			alarm:      alarm,
+			want:       []int{2, 2, 2, 3, 3, 3, 3, 3, 3, 3},
+			finalState: "ALARM",
+		},
+		{
+			name:       "five minute period",
+			group:      SimGroup{MinSize: 1, MaxSize: 5, DesiredCapacity: 1},
+			policy:     SimPolicy{Name: "scale-up", ScalingAdjustment: 1, Cooldown: 60},
+			alarm:      SimAlarm{Name: "cpu-high", Threshold: 70, ComparisonOperator: "GreaterThanThreshold", Period: 300, EvaluationPeriods: 1, AlarmActions: []string{"scale-up"}},
+			want:       []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 2},
+			finalState: "ALARM",
+		},
+	}
+
+	for _, test := range tests {
+		steps := SimulateScaling(test.group, []SimAlarm{test.alarm}, map[string]SimPolicy{test.policy.Name: test.policy}, curve)
+
+		var got []int
+		for _, step := range steps {
+			got = append(got, step.Capacity)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: capacity = %v, want %v", test.name, got, test.want)
+		}
+		if state := steps[len(steps)-1].AlarmStates["cpu-high"]; state != test.finalState {
+			t.Errorf("%s: final alarm state = %s, want %s", test.name, state, test.finalState)
+		}
+	}
+}
+
+func TestSimulateScalingAlarmStates(t *testing.T) {
+	alarm := SimAlarm{Name: "cpu-low", Threshold: 20, ComparisonOperator: "LessThanOrEqualToThreshold", Period: 60, EvaluationPeriods: 1}
+	steps := SimulateScaling(SimGroup{MinSize: 1, MaxSize: 1, DesiredCapacity: 1}, []SimAlarm{alarm}, nil, []float64{50, 20, 10, 30})
+
+	want := []string{"OK", "ALARM", "ALARM", "OK"}
+	for i, step := range steps {
+		if state := step.AlarmStates["cpu-low"]; state != want[i] {
+			t.Errorf("minute %d: alarm state = %s, want %s", step.Minute, state, want[i])
+		}
+	}
+	if len(steps[1].Events) != 1 || len(steps[2].Events) != 0 {
+		t.Errorf("events = %v, want a single transition at minute 2", [][]string{steps[1].Events, steps[2].Events})
+	}
+}
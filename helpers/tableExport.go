@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+)
+
+// TableCSV writes a table as CSV
+func TableCSV(header []string, rows [][]string) []byte {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	w.Write(header)
+	w.WriteAll(rows)
+
+	return buf.Bytes()
+}
+
+// TableMarkdown writes a table as a GitHub flavored Markdown table, for pasting into tickets
+func TableMarkdown(header []string, rows [][]string) []byte {
+	buf := new(bytes.Buffer)
+
+	writeMarkdownRow(buf, header)
+
+	divider := make([]string, len(header))
+	for i := range divider {
+		divider[i] = "---"
+	}
+	writeMarkdownRow(buf, divider)
+
+	for _, row := range rows {
+		writeMarkdownRow(buf, row)
+	}
+
+	return buf.Bytes()
+}
+
+func writeMarkdownRow(buf *bytes.Buffer, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = strings.Replace(cell, "|", "\\|", -1)
+		escaped[i] = strings.Replace(strings.Replace(cell, "\r", "", -1), "\n", "<br>", -1)
+	}
+	buf.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import "testing"
+
+func TestTableCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		rows   [][]string
+		want   string
+	}{
+		{
+			name:   "plain",
+			header: []string{"Name", "State"},
+			rows:   [][]string{{"web-v1", "running"}, {"web-v2", "stopped"}},
+			want:   "Name,State\nweb-v1,running\nweb-v2,stopped\n",
+		},
+		{
+			name:   "no rows",
+			header: []string{"Name", "State"},
+			want:   "Name,State\n",
+		},
+		{
+			name:   "commas and quotes",
+			header: []string{"Name", "Tags"},
+			rows:   [][]string{{"web", "env=prod, team=web"}, {`the "main" vpc`, ""}},
+			want:   "Name,Tags\nweb,\"env=prod, team=web\"\n\"the \"\"main\"\" vpc\",\n",
+		},
+		{
+			name:   "newlines",
+			header: []string{"Name", "Description"},
+			rows:   [][]string{{"web", "first line\nsecond line"}},
+			want:   "Name,Description\nweb,\"first line\nsecond line\"\n",
+		},
+		{
+			name:   "leading space",
+			header: []string{"Name"},
+			rows:   [][]string{{" web"}},
+			want:   "Name\n\" web\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		if got := string(TableCSV(test.header, test.rows)); got != test.want {
+			t.Errorf("%s: TableCSV() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTableMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		rows   [][]string
+		want   string
+	}{
+		{
+			name:   "plain",
+			header: []string{"Name", "State"},
+			rows:   [][]string{{"web-v1", "running"}},
+			want:   "| Name | State |\n| --- | --- |\n| web-v1 | running |\n",
+		},
+		{
+			name:   "no rows",
+			header: []string{"Name"},
+			want:   "| Name |\n| --- |\n",
+		},
+		{
+			name:   "pipes",
+			header: []string{"Name", "Rule"},
+			rows:   [][]string{{"web", "tcp|443"}, {"a|b|c", ""}},
+			want:   "| Name | Rule |\n| --- | --- |\n| web | tcp\\|443 |\n| a\\|b\\|c |  |\n",
+		},
+		{
+			name:   "newlines",
+			header: []string{"Name", "Description"},
+			rows:   [][]string{{"web", "first line\r\nsecond line\nthird"}},
+			want:   "| Name | Description |\n| --- | --- |\n| web | first line<br>second line<br>third |\n",
+		},
+	}
+
+	for _, test := range tests {
+		if got := string(TableMarkdown(test.header, test.rows)); got != test.want {
+			t.Errorf("%s: TableMarkdown() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+const tableViewsKey = "awsmTableViews."
+
+// TableView is a saved way of looking at an asset table: which columns in what order, the tag filters and the sort
+type TableView struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns,omitempty"` // shown columns in order, every column when empty
+	TagFilters []string `json:"tagFilters,omitempty"`
+	TagColumns []string `json:"tagColumns,omitempty"`
+	SortColumn string   `json:"sortColumn,omitempty"`
+	SortDesc   bool     `json:"sortDesc,omitempty"`
+}
+
+type tableViews []TableView
+
+func (t tableViews) Len() int           { return len(t) }
+func (t tableViews) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t tableViews) Less(i, j int) bool { return t[i].Name < t[j].Name }
+
+// LoadTableViews returns the views saved in local storage for an asset type, sorted by name
+func LoadTableViews(assetType string) []TableView {
+	var views []TableView
+
+	stored := js.Global.Get("localStorage").Call("getItem", tableViewsKey+assetType)
+	if stored == nil || stored == js.Undefined {
+		return views
+	}
+	json.Unmarshal([]byte(stored.String()), &views)
+	sort.Sort(tableViews(views))
+
+	return views
+}
+
+// SaveTableView stores a view for an asset type, replacing any saved view with the same name
+func SaveTableView(assetType string, view TableView) {
+	views := []TableView{view}
+	for _, v := range LoadTableViews(assetType) {
+		if v.Name != view.Name {
+			views = append(views, v)
+		}
+	}
+	storeTableViews(assetType, views)
+}
+
+// DeleteTableView removes a saved view from an asset type
+func DeleteTableView(assetType, name string) {
+	var views []TableView
+	for _, v := range LoadTableViews(assetType) {
+		if v.Name != name {
+			views = append(views, v)
+		}
+	}
+	storeTableViews(assetType, views)
+}
+
+// FindTableView returns the saved view with the name
+func FindTableView(assetType, name string) (TableView, bool) {
+	for _, v := range LoadTableViews(assetType) {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return TableView{}, false
+}
+
+func storeTableViews(assetType string, views []TableView) {
+	data, _ := json.Marshal(views)
+	js.Global.Get("localStorage").Call("setItem", tableViewsKey+assetType, string(data))
+}
+
+// EncodeTableView packs a view into a url safe string, for sharing as "?view="
+func EncodeTableView(view TableView) string {
+	data, _ := json.Marshal(view)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTableView unpacks a view shared in a url
+func DecodeTableView(encoded string) (TableView, error) {
+	var view TableView
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return view, err
+	}
+	err = json.Unmarshal(data, &view)
+
+	return view, err
+}
+
+// CompareCells orders two table cells, as numbers when both are numbers (ignoring "$", "," and units like "/mo"),
+// otherwise as text
+func CompareCells(a, b string) int {
+	numA, errA := strconv.ParseFloat(cellNumber(a), 64)
+	numB, errB := strconv.ParseFloat(cellNumber(b), 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// cellNumber strips a cell down to the number in it, "$1,024.50/mo" is "1024.50"
+func cellNumber(cell string) string {
+	cell = strings.Replace(strings.TrimPrefix(strings.TrimSpace(cell), "$"), ",", "", -1)
+	return strings.TrimRightFunc(cell, func(r rune) bool { return !unicode.IsDigit(r) })
+}
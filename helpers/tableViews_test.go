@@ -0,0 +1,90 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestCompareCells(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2", "10", -1},
+		{"10", "2", 1},
+		{"1,024", "999", 1},
+		{"$73.00/mo", "$100.00/mo", -1},
+		{"$1,200.00/mo", "$100.00/mo", 1},
+		{"$73.00/mo", "$73.00/mo", 0},
+		{"8 GB", "16 GB", -1},
+		{" 5 ", "5", 0},
+		{"-1.5", "2", -1},
+		{"web", "Api", 1},
+		{"api", "API", 0},
+		{"i-10", "i-9", -1},
+		{"-", "$73.00/mo", 1}, // unpriced rows go after the costs
+		{"", "1", -1},
+	}
+
+	for _, test := range tests {
+		if got := CompareCells(test.a, test.b); got != test.want {
+			t.Errorf("CompareCells(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCompareCellsOrdersCosts(t *testing.T) {
+	costs := []string{FormatCost(9.5), FormatCost(73), FormatCost(100), FormatCost(1200)}
+	for i := 1; i < len(costs); i++ {
+		if got := CompareCells(costs[i-1], costs[i]); got != -1 {
+			t.Errorf("CompareCells(%q, %q) = %d, want -1", costs[i-1], costs[i], got)
+		}
+	}
+}
+
+func TestTableViewEncoding(t *testing.T) {
+	views := []TableView{
+		{},
+		{Name: "prod web"},
+		{
+			Name:       "by cost",
+			Columns:    []string{"Name", "Monthly Cost", "Instance ID"},
+			TagFilters: []string{"env=prod", "team"},
+			TagColumns: []string{"owner"},
+			SortColumn: "Monthly Cost",
+			SortDesc:   true,
+		},
+		{Name: "odd/chars?&=", TagFilters: []string{"name=a+b c"}},
+	}
+
+	for _, view := range views {
+		encoded := EncodeTableView(view)
+		got, err := DecodeTableView(encoded)
+		if err != nil {
+			t.Errorf("DecodeTableView(EncodeTableView(%+v)) error: %s", view, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, view) {
+			t.Errorf("DecodeTableView(EncodeTableView(%+v)) = %+v", view, got)
+		}
+	}
+}
+
+func TestDecodeTableViewMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"not base64!",
+		"eyJuYW1lIjoid2ViIn0=", // padded, the shared urls are not
+		base64.RawURLEncoding.EncodeToString([]byte("not json")),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"name": "web"`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"columns": "Name"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`["web"]`)),
+	}
+
+	for _, encoded := range tests {
+		if view, err := DecodeTableView(encoded); err == nil {
+			t.Errorf("DecodeTableView(%q) = %+v, want an error", encoded, view)
+		}
+	}
+}
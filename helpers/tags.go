@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Jeffail/gabs"
+)
+
+// TagFilter matches assets with a tag, "key=value", or with a tag key set to anything, "key"
+type TagFilter struct {
+	Key   string
+	Value string
+	Any   bool
+}
+
+// ParseTagFilter reads a filter chip, "env=prod" or just "env"
+func ParseTagFilter(filter string) TagFilter {
+	parts := strings.SplitN(filter, "=", 2)
+	if len(parts) == 1 {
+		return TagFilter{Key: strings.TrimSpace(parts[0]), Any: true}
+	}
+	return TagFilter{Key: strings.TrimSpace(parts[0]), Value: strings.TrimSpace(parts[1])}
+}
+
+func (t TagFilter) String() string {
+	if t.Any {
+		return t.Key
+	}
+	return t.Key + "=" + t.Value
+}
+
+// Matches returns true if the tags satisfy the filter
+func (t TagFilter) Matches(tags map[string]string) bool {
+	value, ok := tags[t.Key]
+	return ok && (t.Any || value == t.Value)
+}
+
+// MatchesTagFilters returns true if the tags satisfy every filter
+func MatchesTagFilters(tags map[string]string, filters []TagFilter) bool {
+	for _, filter := range filters {
+		if !filter.Matches(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssetTags reads the tags of any asset type, which come back either as a map or as a list of key/value pairs
+func AssetTags(asset *gabs.Container) map[string]string {
+	tags := make(map[string]string)
+
+	for _, field := range []string{"tags", "Tags"} {
+		switch t := asset.S(field).Data().(type) {
+		case map[string]interface{}:
+			for key, value := range t {
+				tags[key] = fmt.Sprint(value)
+			}
+		case []interface{}:
+			for _, tag := range t {
+				kv, ok := tag.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				key, _ := firstValue(kv, "key", "Key").(string)
+				if key != "" {
+					tags[key] = fmt.Sprint(firstValue(kv, "value", "Value"))
+				}
+			}
+		}
+	}
+
+	return tags
+}
+
+// AssetID returns an asset's own id from its id field, key is the field awsm names it with, "instanceID". Older
+// asset lists capitalize it, "InstanceID"
+func AssetID(asset *gabs.Container, key string) string {
+	if key == "" {
+		return ""
+	}
+
+	for _, field := range []string{key, strings.ToUpper(key[:1]) + key[1:]} {
+		if id, ok := asset.S(field).Data().(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// TagOptions returns every tag key, and every "key=value" pair, used across the tag sets
+func TagOptions(tagSets []map[string]string) ([]string, []string) {
+	keySet := make(map[string]bool)
+	pairSet := make(map[string]bool)
+	for _, tags := range tagSets {
+		for key, value := range tags {
+			keySet[key] = true
+			pairSet[key+"="+value] = true
+		}
+	}
+
+	var keys, pairs []string
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	for pair := range pairSet {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(keys)
+	sort.Strings(pairs)
+
+	return keys, pairs
+}
+
+// UpdateAssetTags adds and removes tags on a set of assets
+func UpdateAssetTags(assetType string, ids []string, add map[string]string, remove []string) error {
+	if len(ids) == 0 {
+		return errors.New("No assets selected!")
+	}
+
+	endpoint := "//localhost:8081/api/assets/" + assetType + "/tags"
+	_, err := PostAPI(endpoint, map[string]interface{}{
+		"ids":    ids,
+		"add":    add,
+		"remove": remove,
+	})
+	if err != nil {
+		return fmt.Errorf("Error while posting to endpoint: %s", endpoint)
+	}
+	return nil
+}
+
+func firstValue(m map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		if value, ok := m[key]; ok && value != nil {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/Jeffail/gabs"
+)
+
+func TestAssetID(t *testing.T) {
+	tests := []struct {
+		asset string
+		key   string
+		want  string
+	}{
+		{`{"subnetID": "subnet-1", "vpcID": "vpc-1"}`, "subnetID", "subnet-1"},
+		{`{"subnetID": "subnet-1", "vpcID": "vpc-1"}`, "vpcID", "vpc-1"},
+		{`{"SubnetID": "subnet-1", "VpcID": "vpc-1"}`, "subnetID", "subnet-1"},
+		{`{"name": "web", "imageID": "ami-1"}`, "name", "web"},
+		{`{"subnetID": "", "vpcID": "vpc-1"}`, "subnetID", ""},
+		{`{"vpcID": "vpc-1"}`, "subnetID", ""},
+		{`{"vpcID": "vpc-1"}`, "", ""},
+		{`{"instanceID": 5}`, "instanceID", ""},
+	}
+
+	for _, test := range tests {
+		asset, err := gabs.ParseJSON([]byte(test.asset))
+		if err != nil {
+			t.Fatalf("bad test asset %s: %s", test.asset, err)
+		}
+		if got := AssetID(asset, test.key); got != test.want {
+			t.Errorf("AssetID(%s, %q) = %q, want %q", test.asset, test.key, got, test.want)
+		}
+	}
+}
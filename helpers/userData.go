@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// UserDataLimit is the most user data EC2 accepts, measured before base64 encoding
+const UserDataLimit = 16 * 1024
+
+// User data formats cloud-init understands
+const (
+	UserDataCloudConfig = "cloud-config"
+	UserDataShell       = "shell"
+	UserDataMultipart   = "mime-multipart"
+	UserDataPowershell  = "powershell"
+	UserDataUnknown     = "unknown"
+)
+
+var userDataVariable = regexp.MustCompile(`{{\s*([A-Za-z0-9_]+)\s*}}`)
+
+// DetectUserDataType works out the user data format from its first line, the way cloud-init does
+func DetectUserDataType(userData string) string {
+	firstLine := strings.TrimSpace(strings.SplitN(strings.TrimLeft(userData, "\r\n\t "), "\n", 2)[0])
+
+	switch {
+	case firstLine == "":
+		return UserDataUnknown
+	case strings.HasPrefix(firstLine, "#cloud-config"):
+		return UserDataCloudConfig
+	case strings.HasPrefix(firstLine, "#!"):
+		return UserDataShell
+	case strings.HasPrefix(strings.ToLower(firstLine), "content-type: multipart/mixed"):
+		return UserDataMultipart
+	case strings.HasPrefix(firstLine, "<powershell>"):
+		return UserDataPowershell
+	}
+	return UserDataUnknown
+}
+
+// UserDataSizes returns the raw and base64 encoded sizes of the user data
+func UserDataSizes(userData string) (int, int) {
+	return len(userData), base64.StdEncoding.EncodedLen(len(userData))
+}
+
+// ValidateUserData returns everything wrong with the user data: size over the limit, bad cloud-config yaml, or an
+// unrecognized format
+func ValidateUserData(userData string) []string {
+	var problems []string
+
+	if strings.TrimSpace(userData) == "" {
+		return problems
+	}
+
+	raw, encoded := UserDataSizes(userData)
+	if raw > UserDataLimit {
+		problems = append(problems, fmt.Sprintf("User data is %d bytes, over the %d byte limit (%d bytes once base64 encoded)", raw, UserDataLimit, encoded))
+	}
+
+	switch DetectUserDataType(userData) {
+	case UserDataCloudConfig:
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal([]byte(userData), &doc); err != nil {
+			problems = append(problems, "cloud-config is not valid YAML: "+err.Error())
+		} else if len(doc) == 0 {
+			problems = append(problems, "cloud-config is empty")
+		}
+	case UserDataUnknown:
+		problems = append(problems, "User data should start with #!, #cloud-config or a MIME multipart header, or cloud-init will ignore it")
+	}
+
+	return problems
+}
+
+// UserDataVariables returns the names of the {{variables}} used in the user data
+func UserDataVariables(userData string) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, match := range userDataVariable.FindAllStringSubmatch(userData, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// InterpolateUserData fills in {{variables}}, returning the result and any variables that have no value
+func InterpolateUserData(userData string, vars map[string]string) (string, []string) {
+	result := userDataVariable.ReplaceAllStringFunc(userData, func(match string) string {
+		name := userDataVariable.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+
+	var missing []string
+	for _, name := range UserDataVariables(userData) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return result, missing
+}
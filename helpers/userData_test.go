@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectUserDataType(t *testing.T) {
+	tests := []struct {
+		userData string
+		want     string
+	}{
+		{userData: "", want: UserDataUnknown},
+		{userData: "#!/bin/bash\necho hi", want: UserDataShell},
+		{userData: "\n\n  #!/usr/bin/env python\n", want: UserDataShell},
+		{userData: "#cloud-config\npackages: [htop]", want: UserDataCloudConfig},
+		{userData: "Content-Type: multipart/mixed; boundary=\"==\"\n", want: UserDataMultipart},
+		{userData: "content-type: MULTIPART/MIXED\n", want: UserDataMultipart},
+		{userData: "<powershell>\nGet-Date\n</powershell>", want: UserDataPowershell},
+		{userData: "echo hi", want: UserDataUnknown},
+	}
+
+	for _, test := range tests {
+		if got := DetectUserDataType(test.userData); got != test.want {
+			t.Errorf("DetectUserDataType(%q) = %s, want %s", test.userData, got, test.want)
+		}
+	}
+}
+
+func TestUserDataSizes(t *testing.T) {
+	tests := []struct {
+		userData     string
+		raw, encoded int
+	}{
+		{userData: "", raw: 0, encoded: 0},
+		{userData: "a", raw: 1, encoded: 4},
+		{userData: "abc", raw: 3, encoded: 4},
+		{userData: "abcd", raw: 4, encoded: 8},
+		{userData: strings.Repeat("a", UserDataLimit), raw: UserDataLimit, encoded: 21848},
+	}
+
+	for _, test := range tests {
+		if raw, encoded := UserDataSizes(test.userData); raw != test.raw || encoded != test.encoded {
+			t.Errorf("UserDataSizes(%d bytes) = %d, %d, want %d, %d", len(test.userData), raw, encoded, test.raw, test.encoded)
+		}
+	}
+}
+
+func TestValidateUserData(t *testing.T) {
+	script := "#!/bin/bash\n"
+
+	tests := []struct {
+		name     string
+		userData string
+		problems int
+		contains string
+	}{
+		{name: "empty", userData: "  \n"},
+		{name: "shell", userData: "#!/bin/bash\necho hi\n"},
+		{name: "at the limit", userData: script + strings.Repeat("a", UserDataLimit-len(script))},
+		{name: "over the limit", userData: script + strings.Repeat("a", UserDataLimit-len(script)+1), problems: 1, contains: "over the 16384 byte limit"},
+		{name: "cloud-config", userData: "#cloud-config\npackages:\n  - htop\n"},
+		{name: "bad cloud-config", userData: "#cloud-config\npackages: [htop\n", problems: 1, contains: "not valid YAML"},
+		{name: "empty cloud-config", userData: "#cloud-config\n", problems: 1, contains: "cloud-config is empty"},
+		{name: "unknown", userData: "echo hi", problems: 1, contains: "cloud-init will ignore it"},
+		{name: "unknown and over the limit", userData: strings.Repeat("a", UserDataLimit+1), problems: 2},
+	}
+
+	for _, test := range tests {
+		problems := ValidateUserData(test.userData)
+		if len(problems) != test.problems {
+			t.Errorf("%s: ValidateUserData() = %v, want %d problems", test.name, problems, test.problems)
+			continue
+		}
+		if test.contains != "" && !strings.Contains(strings.Join(problems, "\n"), test.contains) {
+			t.Errorf("%s: ValidateUserData() = %v, want a problem containing %q", test.name, problems, test.contains)
+		}
+	}
+}
+
+func TestInterpolateUserData(t *testing.T) {
+	vars := map[string]string{"class": "web", "vpc": "main", "keyName": ""}
+
+	tests := []struct {
+		userData  string
+		want      string
+		variables []string
+		missing   []string
+	}{
+		{userData: "#!/bin/bash\n", want: "#!/bin/bash\n"},
+		{userData: "hostname {{class}}", want: "hostname web", variables: []string{"class"}},
+		{userData: "{{ class }}-{{vpc}}-{{class}}", want: "web-main-web", variables: []string{"class", "vpc"}},
+		{userData: "key={{keyName}}", want: "key=", variables: []string{"keyName"}},
+		{userData: "{{subnet}} {{class}}", want: "{{subnet}} web", variables: []string{"class", "subnet"}, missing: []string{"subnet"}},
+		{userData: "${HOME} {class} {{not valid}}", want: "${HOME} {class} {{not valid}}"},
+	}
+
+	for _, test := range tests {
+		if got := UserDataVariables(test.userData); !reflect.DeepEqual(got, test.variables) {
+			t.Errorf("UserDataVariables(%q) = %v, want %v", test.userData, got, test.variables)
+		}
+
+		got, missing := InterpolateUserData(test.userData, vars)
+		if got != test.want || !reflect.DeepEqual(missing, test.missing) {
+			t.Errorf("InterpolateUserData(%q) = %q, %v, want %q, %v", test.userData, got, missing, test.want, test.missing)
+		}
+	}
+}
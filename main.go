@@ -11,110 +11,7 @@ import (
 var (
 	brand = "awsm"
 
-	pages = components.Pages{
-		"Dashboard": components.Page{
-			Route:      "/",
-			ApiType:    "dashboard",
-			Type:       "Dashboard",
-			HasWidgets: true,
-		},
-		"Instances": components.Page{
-			Route:      "/instances",
-			ApiType:    "instances",
-			Type:       "Instance",
-			HasClasses: true,
-		},
-		"Volumes": components.Page{
-			Route:      "/volumes",
-			ApiType:    "volumes",
-			Type:       "Volume",
-			HasClasses: true,
-		},
-		"Images": components.Page{
-			Route:      "/images",
-			ApiType:    "images",
-			Type:       "Image",
-			HasClasses: true,
-		},
-		"Snapshots": components.Page{
-			Route:      "/snapshots",
-			ApiType:    "snapshots",
-			Type:       "Snapshot",
-			HasClasses: true,
-		},
-		"Vpcs": components.Page{
-			Route:      "/vpcs",
-			ApiType:    "vpcs",
-			Type:       "Vpc",
-			HasClasses: true,
-		},
-		"Subnets": components.Page{
-			Route:      "/subnets",
-			ApiType:    "subnets",
-			Type:       "Subnet",
-			HasClasses: true,
-		},
-		"Security Groups": components.Page{
-			Route:      "/securitygroups",
-			ApiType:    "securitygroups",
-			Type:       "Security Group",
-			HasClasses: true,
-		},
-		"Addresses": components.Page{
-			Route:      "/addresses",
-			ApiType:    "addresses",
-			Type:       "Address",
-			HasClasses: false,
-		},
-		"Alarms": components.Page{
-			Route:      "/alarms",
-			ApiType:    "alarms",
-			Type:       "Alarm",
-			HasClasses: true,
-		},
-		"Key Pairs": components.Page{
-			Route:      "/keypairs",
-			ApiType:    "keypairs",
-			Type:       "Key Pair",
-			HasClasses: true,
-		},
-		"Launch Configurations": components.Page{
-			Route:      "/launchconfigurations",
-			ApiType:    "launchconfigurations",
-			Type:       "Launch Configuration",
-			HasClasses: true,
-		},
-		"Autoscale Groups": components.Page{
-			Route:      "/autoscalegroups",
-			ApiType:    "autoscalegroups",
-			Type:       "Autoscale Group",
-			HasClasses: true,
-		},
-		"Load Balancers": components.Page{
-			Route:      "/loadbalancers",
-			ApiType:    "loadbalancers",
-			Type:       "Load Balancer",
-			HasClasses: true,
-		},
-		"Scaling Policies": components.Page{
-			Route:      "/scalingpolicies",
-			ApiType:    "scalingpolicies",
-			Type:       "Scaling Policy",
-			HasClasses: true,
-		},
-		"SimpleDB Domains": components.Page{
-			Route:      "/simpledbdomains",
-			ApiType:    "simpledbdomains",
-			Type:       "SimpleDB Domain",
-			HasClasses: false,
-		},
-		"S3 Buckets": components.Page{
-			Route:      "/buckets",
-			ApiType:    "buckets",
-			Type:       "S3 Buckets",
-			HasClasses: false,
-		},
-	}
+	pages = components.AppPages()
 
 	reactRouter = js.Global.Get("ReactRouter")
 
@@ -124,6 +21,10 @@ var (
 
 func main() {
 
+	for _, problem := range components.CheckAssetTypes(pages) {
+		println("Asset type registration: " + problem)
+	}
+
 	var routes []grouter.Route
 
 	for name, page := range pages {